@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"time"
 
 	"github.com/xzl-go/nova/config"
@@ -31,19 +32,59 @@ func GenerateToken(userID uint, username string) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(*conf.JWT.Secret)
+	return token.SignedString([]byte(*conf.JWT.Secret))
 }
 
-// ParseToken 解析JWT令牌
+// ErrMissingKey 未提供签名密钥或密钥函数
+var ErrMissingKey = errors.New("auth: missing signing key")
+
+// ParseOptions 令牌校验选项
+type ParseOptions struct {
+	Secret   []byte        // HS256 签名密钥
+	KeyFunc  jwt.Keyfunc   // 自定义密钥函数，设置时优先于 Secret，需自行校验签名算法
+	Issuer   string        // 要求的签发者，为空表示不校验
+	Audience string        // 要求的受众，为空表示不校验
+	Leeway   time.Duration // 校验过期与生效时间时允许的时钟偏差
+}
+
+// ParseToken 使用配置中的 jwt.secret 解析JWT令牌
 func ParseToken(tokenString string) (*Claims, error) {
 	conf := config.NewConfig().UnmarshalToConfigStruct()
+	claims, err := ParseTokenWithOptions(tokenString, ParseOptions{Secret: []byte(*conf.JWT.Secret)})
+	if err != nil && logger.Log != nil {
+		logger.Error("Failed to parse token", logger.Field("error", err))
+	}
+	return claims, err
+}
 
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return *conf.JWT.Secret, nil
-	})
+// ParseTokenWithOptions 按选项解析并校验JWT令牌，使用 Secret 时仅接受 HS256 签名
+// Secret 与 KeyFunc 均为空时返回 ErrMissingKey
+func ParseTokenWithOptions(tokenString string, opts ParseOptions) (*Claims, error) {
+	var parserOpts []jwt.ParserOption
+	keyFunc := opts.KeyFunc
+	if keyFunc == nil {
+		if len(opts.Secret) == 0 {
+			return nil, ErrMissingKey
+		}
+		secret := opts.Secret
+		keyFunc = func(token *jwt.Token) (interface{}, error) {
+			return secret, nil
+		}
+		parserOpts = append(parserOpts, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	}
+
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+	if opts.Leeway > 0 {
+		parserOpts = append(parserOpts, jwt.WithLeeway(opts.Leeway))
+	}
 
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keyFunc, parserOpts...)
 	if err != nil {
-		logger.Error("Failed to parse token", logger.Field("error", err))
 		return nil, err
 	}
 
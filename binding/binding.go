@@ -1,14 +1,10 @@
 package binding
 
 import (
-	"encoding/json"
+	"context"
 	"encoding/xml"
 	"errors"
 	"net/http"
-	"reflect"
-	"strconv"
-
-	"github.com/xzl-go/nova/validator"
 )
 
 // Binding 参数绑定接口
@@ -17,22 +13,33 @@ type Binding interface {
 	Bind(*http.Request, interface{}) error
 }
 
-// JSONBinding JSON 绑定
-type JSONBinding struct{}
+// Decoder 仅解码请求数据、不执行校验的绑定器
+// 用于从多个数据源组合填充同一结构体后再统一校验
+type Decoder interface {
+	Decode(*http.Request, interface{}) error
+}
+
+// JSONBinding JSON 绑定，Options 为 nil 时使用标准库默认行为
+type JSONBinding struct {
+	Options *JSONOptions
+}
 
 func (JSONBinding) Name() string {
 	return "json"
 }
 
-func (JSONBinding) Bind(req *http.Request, obj interface{}) error {
+func (b JSONBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "json")
+}
+
+func (b JSONBinding) Decode(req *http.Request, obj interface{}) error {
 	if req.Body == nil {
 		return errors.New("invalid request")
 	}
-	decoder := json.NewDecoder(req.Body)
-	if err := decoder.Decode(obj); err != nil {
-		return err
-	}
-	return validator.ValidateStruct(obj)
+	return decodeJSON(req.Body, b.Options, obj)
 }
 
 // XMLBinding XML 绑定
@@ -42,15 +49,19 @@ func (XMLBinding) Name() string {
 	return "xml"
 }
 
-func (XMLBinding) Bind(req *http.Request, obj interface{}) error {
+func (b XMLBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "xml")
+}
+
+func (XMLBinding) Decode(req *http.Request, obj interface{}) error {
 	if req.Body == nil {
 		return errors.New("invalid request")
 	}
 	decoder := xml.NewDecoder(req.Body)
-	if err := decoder.Decode(obj); err != nil {
-		return err
-	}
-	return validator.ValidateStruct(obj)
+	return decoder.Decode(obj)
 }
 
 // FormBinding Form 绑定
@@ -60,22 +71,42 @@ func (FormBinding) Name() string {
 	return "form"
 }
 
-func (FormBinding) Bind(req *http.Request, obj interface{}) error {
+func (b FormBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "form")
+}
+
+func (FormBinding) Decode(req *http.Request, obj interface{}) error {
 	if err := req.ParseForm(); err != nil {
 		return err
 	}
-	return mapForm(obj, req.Form)
+	return decodeError(mapForm(obj, req.Form))
 }
 
 // QueryBinding Query 绑定
-type QueryBinding struct{}
+type QueryBinding struct {
+	// SkipDefaults 不应用 default 标签，用于多来源合并绑定时避免覆盖其他来源已绑定的值
+	SkipDefaults bool
+}
 
 func (QueryBinding) Name() string {
 	return "query"
 }
 
-func (QueryBinding) Bind(req *http.Request, obj interface{}) error {
-	return mapForm(obj, req.URL.Query())
+func (b QueryBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "form")
+}
+
+func (b QueryBinding) Decode(req *http.Request, obj interface{}) error {
+	if b.SkipDefaults {
+		return decodeError(mapWithoutDefaults(obj, req.URL.Query(), "form"))
+	}
+	return decodeError(mapForm(obj, req.URL.Query()))
 }
 
 // FormPostBinding Form Post 绑定
@@ -85,25 +116,94 @@ func (FormPostBinding) Name() string {
 	return "form-urlencoded"
 }
 
-func (FormPostBinding) Bind(req *http.Request, obj interface{}) error {
+func (b FormPostBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "form")
+}
+
+func (FormPostBinding) Decode(req *http.Request, obj interface{}) error {
 	if err := req.ParseForm(); err != nil {
 		return err
 	}
-	return mapForm(obj, req.PostForm)
+	return decodeError(mapForm(obj, req.PostForm))
 }
 
-// FormMultipartBinding Form Multipart 绑定
+// FormMultipartBinding Form Multipart 绑定，*multipart.FileHeader 与 []*multipart.FileHeader 字段从上传文件中填充
 type FormMultipartBinding struct{}
 
 func (FormMultipartBinding) Name() string {
 	return "multipart/form-data"
 }
 
-func (FormMultipartBinding) Bind(req *http.Request, obj interface{}) error {
+func (b FormMultipartBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "form")
+}
+
+func (FormMultipartBinding) Decode(req *http.Request, obj interface{}) error {
 	if err := req.ParseMultipartForm(defaultMemory); err != nil {
 		return err
 	}
-	return mapForm(obj, req.MultipartForm.Value)
+	return decodeError(mapMultipartForm(obj, req.MultipartForm))
+}
+
+// URIBinding 路由参数绑定，使用 uri 标签
+type URIBinding struct {
+	// SkipDefaults 不应用 default 标签，用于多来源合并绑定时避免覆盖其他来源已绑定的值
+	SkipDefaults bool
+}
+
+func (URIBinding) Name() string {
+	return "uri"
+}
+
+// BindUri 将路由参数绑定到结构体并校验，校验不使用请求 context，请求处理中应使用 BindUriCtx
+func (b URIBinding) BindUri(params map[string][]string, obj interface{}) error {
+	return b.BindUriCtx(context.Background(), params, obj)
+}
+
+// BindUriCtx 将路由参数绑定到结构体并使用 ctx 校验，ctx 中的校验分组、校验缓存与超时均生效
+func (b URIBinding) BindUriCtx(ctx context.Context, params map[string][]string, obj interface{}) error {
+	if err := b.DecodeUri(params, obj); err != nil {
+		return err
+	}
+	return ValidateCtx(ctx, obj, "uri", GroupsFromContext(ctx)...)
+}
+
+// DecodeUri 将路由参数绑定到结构体，不执行校验
+func (b URIBinding) DecodeUri(params map[string][]string, obj interface{}) error {
+	if b.SkipDefaults {
+		return decodeError(mapWithoutDefaults(obj, params, "uri"))
+	}
+	return decodeError(mapFormByTag(obj, params, "uri"))
+}
+
+// HeaderBinding 请求头绑定，使用 header 标签，名称按 HTTP 头规范化后匹配
+type HeaderBinding struct {
+	// SkipDefaults 不应用 default 标签，用于多来源合并绑定时避免覆盖其他来源已绑定的值
+	SkipDefaults bool
+}
+
+func (HeaderBinding) Name() string {
+	return "header"
+}
+
+func (b HeaderBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "header")
+}
+
+func (b HeaderBinding) Decode(req *http.Request, obj interface{}) error {
+	if b.SkipDefaults {
+		return decodeError(mapWithoutDefaults(obj, req.Header, "header"))
+	}
+	return decodeError(mapFormByTag(obj, req.Header, "header"))
 }
 
 // 默认内存大小
@@ -117,131 +217,10 @@ var (
 	Query         = QueryBinding{}
 	FormPost      = FormPostBinding{}
 	FormMultipart = FormMultipartBinding{}
+	Uri           = URIBinding{}
+	Header        = HeaderBinding{}
+	YAML          = YAMLBinding{}
+	TOML          = TOMLBinding{}
+	ProtoBuf      = ProtoBufBinding{}
+	MsgPack       = MsgPackBinding{}
 )
-
-// mapForm 将表单数据映射到结构体
-func mapForm(ptr interface{}, form map[string][]string) error {
-	typ := reflect.TypeOf(ptr).Elem()
-	val := reflect.ValueOf(ptr).Elem()
-
-	for i := 0; i < typ.NumField(); i++ {
-		typeField := typ.Field(i)
-		structField := val.Field(i)
-		if !structField.CanSet() {
-			continue
-		}
-
-		structFieldKind := structField.Kind()
-		inputFieldName := typeField.Tag.Get("form")
-		if inputFieldName == "" {
-			inputFieldName = typeField.Name
-		}
-
-		inputValue, exists := form[inputFieldName]
-		if !exists {
-			continue
-		}
-
-		numElems := len(inputValue)
-		if structFieldKind == reflect.Slice && numElems > 0 {
-			sliceOf := structField.Type().Elem().Kind()
-			slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
-			for j := 0; j < numElems; j++ {
-				if err := setWithProperType(sliceOf, inputValue[j], slice.Index(j)); err != nil {
-					return err
-				}
-			}
-			val.Field(i).Set(slice)
-		} else {
-			if err := setWithProperType(typeField.Type.Kind(), inputValue[0], structField); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-// setWithProperType 设置适当类型的值
-func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value) error {
-	switch valueKind {
-	case reflect.Int:
-		return setIntField(val, 0, structField)
-	case reflect.Int8:
-		return setIntField(val, 8, structField)
-	case reflect.Int16:
-		return setIntField(val, 16, structField)
-	case reflect.Int32:
-		return setIntField(val, 32, structField)
-	case reflect.Int64:
-		return setIntField(val, 64, structField)
-	case reflect.Uint:
-		return setUintField(val, 0, structField)
-	case reflect.Uint8:
-		return setUintField(val, 8, structField)
-	case reflect.Uint16:
-		return setUintField(val, 16, structField)
-	case reflect.Uint32:
-		return setUintField(val, 32, structField)
-	case reflect.Uint64:
-		return setUintField(val, 64, structField)
-	case reflect.Bool:
-		return setBoolField(val, structField)
-	case reflect.Float32:
-		return setFloatField(val, 32, structField)
-	case reflect.Float64:
-		return setFloatField(val, 64, structField)
-	case reflect.String:
-		structField.SetString(val)
-	default:
-		return errors.New("unknown type")
-	}
-	return nil
-}
-
-// setIntField 设置整型字段
-func setIntField(val string, bitSize int, field reflect.Value) error {
-	if val == "" {
-		val = "0"
-	}
-	intVal, err := strconv.ParseInt(val, 10, bitSize)
-	if err == nil {
-		field.SetInt(intVal)
-	}
-	return err
-}
-
-// setUintField 设置无符号整型字段
-func setUintField(val string, bitSize int, field reflect.Value) error {
-	if val == "" {
-		val = "0"
-	}
-	uintVal, err := strconv.ParseUint(val, 10, bitSize)
-	if err == nil {
-		field.SetUint(uintVal)
-	}
-	return err
-}
-
-// setBoolField 设置布尔字段
-func setBoolField(val string, field reflect.Value) error {
-	if val == "" {
-		val = "false"
-	}
-	boolVal, err := strconv.ParseBool(val)
-	if err == nil {
-		field.SetBool(boolVal)
-	}
-	return err
-}
-
-// setFloatField 设置浮点数字段
-func setFloatField(val string, bitSize int, field reflect.Value) error {
-	if val == "" {
-		val = "0.0"
-	}
-	floatVal, err := strconv.ParseFloat(val, bitSize)
-	if err == nil {
-		field.SetFloat(floatVal)
-	}
-	return err
-}
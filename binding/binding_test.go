@@ -0,0 +1,282 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xzl-go/nova/i18n"
+	"github.com/xzl-go/nova/validator"
+)
+
+type bindingItem struct {
+	ID int `json:"id" form:"item_id" validate:"required"`
+}
+
+type bindingPage struct {
+	Size int `json:"size" form:"size" validate:"max=100"`
+}
+
+type bindingRequest struct {
+	bindingPage
+	Name  string        `json:"name" form:"user_name" validate:"required"`
+	Items []bindingItem `json:"items" form:"items" validate:"dive"`
+}
+
+// 测试各绑定器统一校验并返回按标签命名的字段路径
+func TestBindValidationError(t *testing.T) {
+	tests := []struct {
+		name    string
+		binding Binding
+		ctype   string
+		target  string
+		body    string
+		want    []FieldError
+	}{
+		{
+			name:    "query",
+			binding: Query,
+			target:  "/?size=200&items[0].item_id=0",
+			want: []FieldError{
+				{Field: "size", Rule: "max", Param: "100"},
+				{Field: "user_name", Rule: "required"},
+				{Field: "items[0].item_id", Rule: "required"},
+			},
+		},
+		{
+			name:    "form",
+			binding: FormPost,
+			ctype:   "application/x-www-form-urlencoded",
+			target:  "/",
+			body:    "user_name=alice&size=x",
+			want:    []FieldError{{Field: "size", Rule: "type"}},
+		},
+		{
+			name:    "json",
+			binding: JSON,
+			ctype:   "application/json",
+			target:  "/",
+			body:    `{"items":[{"id":0}]}`,
+			want: []FieldError{
+				{Field: "name", Rule: "required"},
+				{Field: "items[0].id", Rule: "required"},
+			},
+		},
+		{
+			name:    "json type",
+			binding: JSON,
+			ctype:   "application/json",
+			target:  "/",
+			body:    `{"name":1}`,
+			want:    []FieldError{{Field: "name", Rule: "type", Param: "string"}},
+		},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+		if tt.ctype != "" {
+			r.Header.Set("Content-Type", tt.ctype)
+		}
+		var req bindingRequest
+		err := tt.binding.Bind(r, &req)
+
+		var bindErr *Error
+		if !errors.As(err, &bindErr) {
+			t.Fatalf("%s: err = %v, want *Error", tt.name, err)
+		}
+		got := make([]FieldError, 0, len(bindErr.Fields))
+		for _, f := range bindErr.Fields {
+			if f.Message == "" {
+				t.Fatalf("%s: empty message for %s", tt.name, f.Field)
+			}
+			f.Message = ""
+			got = append(got, f)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("%s: fields = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+type uploadRequest struct {
+	Title  string                  `form:"title" validate:"required"`
+	Avatar *multipart.FileHeader   `form:"avatar" validate:"required,file_max=1KB,mime=image/png image/jpeg"`
+	Docs   []*multipart.FileHeader `form:"docs" validate:"max_files=2,mime=text/*"`
+}
+
+// 测试上传文件绑定到结构体字段并按大小、类型与数量校验
+func TestBindMultipartFiles(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 16))
+	tests := []struct {
+		name   string
+		avatar []byte
+		docs   int
+		want   string
+	}{
+		{"ok", png, 2, ""},
+		{"too large", append(png, bytes.Repeat([]byte{0}, 2048)...), 1, "file_max"},
+		{"wrong type", []byte("plain text"), 1, "mime"},
+		{"too many", png, 3, "max_files"},
+	}
+	for _, tt := range tests {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		w.WriteField("title", "hello")
+		fw, _ := w.CreateFormFile("avatar", "a.png")
+		fw.Write(tt.avatar)
+		for i := 0; i < tt.docs; i++ {
+			fw, _ = w.CreateFormFile("docs", "d.txt")
+			fw.Write([]byte("some text"))
+		}
+		w.Close()
+
+		r := httptest.NewRequest("POST", "/", &body)
+		r.Header.Set("Content-Type", w.FormDataContentType())
+		var req uploadRequest
+		err := FormMultipart.Bind(r, &req)
+
+		if tt.want == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error %v", tt.name, err)
+			}
+			if req.Avatar == nil || req.Avatar.Filename != "a.png" || len(req.Docs) != tt.docs {
+				t.Fatalf("%s: files not bound: %+v", tt.name, req)
+			}
+			continue
+		}
+		var bindErr *Error
+		if !errors.As(err, &bindErr) || len(bindErr.Fields) != 1 || bindErr.Fields[0].Rule != tt.want {
+			t.Fatalf("%s: err = %v, want rule %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+// 测试 mime 规则的多类型写法：| 会被解析为规则之间的“或”，给出明确的 panic 信息；0x7C 可正常使用
+func TestMIMESeparators(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 16))
+	newRequest := func(data []byte) *http.Request {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		fw, _ := w.CreateFormFile("avatar", "a.png")
+		fw.Write(data)
+		w.Close()
+		r := httptest.NewRequest("POST", "/", &body)
+		r.Header.Set("Content-Type", w.FormDataContentType())
+		return r
+	}
+
+	t.Run("pipe", func(t *testing.T) {
+		var req struct {
+			Avatar *multipart.FileHeader `form:"avatar" validate:"file_max=5MB,mime=image/png|image/jpeg"`
+		}
+		defer func() {
+			msg, _ := recover().(string)
+			if !strings.Contains(msg, "validator: Undefined validation function 'image/jpeg'") || !strings.Contains(msg, "0x7C") {
+				t.Fatalf("panic = %q, want explanation of the mime separator", msg)
+			}
+		}()
+		FormMultipart.Bind(newRequest(png), &req)
+	})
+
+	t.Run("escaped pipe", func(t *testing.T) {
+		var req struct {
+			Avatar *multipart.FileHeader `form:"avatar" validate:"file_max=5MB,mime=image/png0x7Cimage/jpeg"`
+		}
+		if err := FormMultipart.Bind(newRequest(png), &req); err != nil {
+			t.Fatalf("png rejected: %v", err)
+		}
+		var bindErr *Error
+		err := FormMultipart.Bind(newRequest([]byte("plain text")), &req)
+		if !errors.As(err, &bindErr) || bindErr.Fields[0].Rule != "mime" {
+			t.Fatalf("text err = %v, want mime", err)
+		}
+	})
+}
+
+type accountRequest struct {
+	ID       int       `json:"id" validate:"required" groups:"update"`
+	Password string    `json:"password" validate:"required" groups:"create"`
+	Confirm  string    `json:"confirm_password" validate:"eqfield=Password"`
+	Type     string    `json:"type"`
+	Company  string    `json:"company" validate:"required_if=Type business"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
+func init() {
+	validator.RegisterStructValidation(func(sl validator.StructLevel) {
+		req := sl.Current().Interface().(accountRequest)
+		if !req.Start.IsZero() && !req.End.After(req.Start) {
+			sl.ReportError(req.End, "end", "End", "date_range", "")
+		}
+	}, accountRequest{})
+	validator.RegisterMessage(i18n.LanguageZhCN, "date_range", "%[1]s 必须晚于开始时间")
+}
+
+// 测试跨字段、条件、结构体级与分组校验
+func TestCrossFieldAndGroupValidation(t *testing.T) {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		req    accountRequest
+		groups []string
+		want   []FieldError
+	}{
+		{
+			name:   "create",
+			req:    accountRequest{Password: "a", Confirm: "b", Type: "business"},
+			groups: []string{"create"},
+			want: []FieldError{
+				{Field: "confirm_password", Rule: "eqfield", Param: "password", Message: "confirm_password 必须与 password 相同"},
+				{Field: "company", Rule: "required_if", Param: "type business", Message: "company 在 type business 时不能为空"},
+			},
+		},
+		{
+			name:   "update",
+			req:    accountRequest{Start: start, End: start},
+			groups: []string{"update"},
+			want: []FieldError{
+				{Field: "id", Rule: "required", Message: "id 不能为空"},
+				{Field: "end", Rule: "date_range", Message: "end 必须晚于开始时间"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		err := ValidateGroups(&tt.req, "json", tt.groups...)
+		var bindErr *Error
+		if !errors.As(err, &bindErr) {
+			t.Fatalf("%s: err = %v, want *Error", tt.name, err)
+		}
+		if !reflect.DeepEqual(bindErr.Fields, tt.want) {
+			t.Fatalf("%s: fields = %+v, want %+v", tt.name, bindErr.Fields, tt.want)
+		}
+	}
+}
+
+type labelRequest struct {
+	Email string `json:"email" label:"email" validate:"required"`
+	Phone string `json:"phone" label:"phone" validate:"required"`
+}
+
+// 测试字段显示名只在 label. 命名空间中翻译，不与规则消息冲突
+func TestLabelNamespace(t *testing.T) {
+	validator.RegisterLabel(i18n.LanguageEnUS, "phone", "Phone number")
+
+	err := Validate(&labelRequest{}, "json")
+	var bindErr *Error
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+	got := bindErr.Localize(i18n.LanguageEnUS).Fields
+	want := []string{"email is required", "Phone number is required"}
+	for i, f := range got {
+		if f.Message != want[i] {
+			t.Errorf("message = %q, want %q", f.Message, want[i])
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/xzl-go/nova/i18n"
+	"github.com/xzl-go/nova/validator"
+)
+
+// FieldError 字段错误
+type FieldError struct {
+	Field   string `json:"field"`           // 字段路径，按绑定器的标签命名，如 items[0].id
+	Rule    string `json:"rule"`            // 未通过的规则，解析失败时为 type
+	Param   string `json:"param,omitempty"` // 规则参数
+	Message string `json:"message"`         // 错误信息
+	Label   string `json:"-"`               // 字段显示名，来自 label 标签，可为翻译目录中的键
+}
+
+// Error 绑定与校验错误，列出所有未通过的字段
+type Error struct {
+	Fields []FieldError `json:"fields"`
+	err    error
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Message)
+	}
+	return "binding: " + strings.Join(parts, "; ")
+}
+
+// Unwrap 返回原始错误，如 validator.ValidationErrors 或 *MappingError
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Localize 返回按指定语言重新生成错误信息的副本
+func (e *Error) Localize(lang i18n.Language) *Error {
+	fields := make([]FieldError, len(e.Fields))
+	for i, f := range e.Fields {
+		f.Message = fieldMessage(lang, f)
+		fields[i] = f
+	}
+	return &Error{Fields: fields, err: e.err}
+}
+
+// fieldMessage 生成字段错误信息，声明了 label 时使用翻译后的显示名
+func fieldMessage(lang i18n.Language, f FieldError) string {
+	name := f.Field
+	if f.Label != "" {
+		name = validator.TranslateLabel(lang, f.Label)
+	}
+	return validator.LocalizedMessage(lang, name, f.Rule, f.Param)
+}
+
+// defaultLang 校验消息的默认语言
+func defaultLang() i18n.Language {
+	return validator.Translator().GetDefaultLang()
+}
+
+// Validate 校验结构体，失败时返回字段路径按 tag 标签命名的 *Error
+// obj 不是结构体指针时不做校验
+func Validate(obj interface{}, tag string) error {
+	return ValidateGroups(obj, tag)
+}
+
+// ValidateGroups 按场景分组校验结构体，分组规则见 validator.ValidateStructGroups
+func ValidateGroups(obj interface{}, tag string, groups ...string) error {
+	return ValidateCtx(context.Background(), obj, tag, groups...)
+}
+
+// ValidateCtx 使用 context 按场景分组校验结构体，ctx 传递给 validator.RegisterValidationCtx 注册的校验函数
+func ValidateCtx(ctx context.Context, obj interface{}, tag string, groups ...string) error {
+	t := reflect.TypeOf(obj)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	err := validator.ValidateStructGroupsCtx(ctx, obj, groups...)
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		info := fieldPath(t.Elem(), fe.StructNamespace(), tag)
+		f := FieldError{
+			Field: info.path,
+			Rule:  fe.Tag(),
+			Param: fieldParam(info.parent, fe.Tag(), fe.Param(), tag),
+			Label: info.label,
+		}
+		f.Message = fieldMessage(defaultLang(), f)
+		fields = append(fields, f)
+	}
+	return &Error{Fields: fields, err: err}
+}
+
+// decodeError 将字段解析错误转换为 *Error，其他错误原样返回
+func decodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var mappingErr *MappingError
+	if errors.As(err, &mappingErr) {
+		return &Error{Fields: []FieldError{typeFieldError(mappingErr.Field, "")}, err: err}
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return &Error{Fields: []FieldError{typeFieldError(typeErr.Field, typeErr.Type.String())}, err: err}
+	}
+	return err
+}
+
+// typeFieldError 创建类型不匹配的字段错误
+func typeFieldError(field, param string) FieldError {
+	return newFieldError(field, "type", param)
+}
+
+// newFieldError 创建字段错误，字段路径为空表示整个请求体
+func newFieldError(field, rule, param string) FieldError {
+	f := FieldError{Field: field, Rule: rule, Param: param}
+	if field == "" {
+		f.Label = "body"
+	}
+	f.Message = fieldMessage(defaultLang(), f)
+	return f
+}
+
+// fieldInfo 校验错误对应的字段信息
+type fieldInfo struct {
+	path   string       // 按标签命名的字段路径
+	label  string       // label 标签
+	parent reflect.Type // 字段所在的结构体类型
+}
+
+// fieldPath 将 validator 的结构体命名空间（如 Req.Items[0].ID）转换为按 tag 标签命名的字段路径，并返回字段的 label 标签
+// 未声明标签的内嵌结构体与表单映射一致，不出现在路径中
+func fieldPath(typ reflect.Type, namespace, tag string) fieldInfo {
+	segments := strings.Split(namespace, ".")[1:]
+	parts := make([]string, 0, len(segments))
+	var info fieldInfo
+	for _, seg := range segments {
+		name, index := seg, ""
+		if i := strings.IndexByte(seg, '['); i >= 0 {
+			name, index = seg[:i], seg[i:]
+		}
+
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return fieldInfo{path: strings.Join(segments, ".")}
+		}
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return fieldInfo{path: strings.Join(segments, ".")}
+		}
+		info.label = field.Tag.Get("label")
+		info.parent = typ
+
+		typ = field.Type
+		for n := strings.Count(index, "["); n > 0; n-- {
+			for typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				typ = typ.Elem()
+			}
+		}
+
+		name = tagName(field, tag)
+		if name == "" {
+			continue
+		}
+		parts = append(parts, name+index)
+	}
+	info.path = strings.Join(parts, ".")
+	return info
+}
+
+// tagName 获取字段按 tag 标签的名称，未声明标签时使用字段名，未声明标签的内嵌结构体返回空字符串
+func tagName(field reflect.StructField, tag string) string {
+	name := strings.Split(field.Tag.Get(tag), ",")[0]
+	if name == "" && field.Anonymous {
+		return ""
+	}
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// 参数中引用同级字段名的规则
+var (
+	// fieldParamRules 参数整体为字段名
+	fieldParamRules = map[string]bool{
+		"eqfield": true, "nefield": true, "gtfield": true, "gtefield": true,
+		"ltfield": true, "ltefield": true, "fieldcontains": true, "fieldexcludes": true,
+	}
+	// pairParamRules 参数为“字段名 值”对
+	pairParamRules = map[string]bool{
+		"required_if": true, "required_unless": true, "excluded_if": true, "excluded_unless": true,
+	}
+	// listParamRules 参数为空格分隔的字段名列表
+	listParamRules = map[string]bool{
+		"required_with": true, "required_with_all": true, "required_without": true, "required_without_all": true,
+		"excluded_with": true, "excluded_with_all": true, "excluded_without": true, "excluded_without_all": true,
+	}
+)
+
+// fieldParam 将跨字段规则参数中的字段名转换为按 tag 标签的名称，如 eqfield=Password 转换为 password
+func fieldParam(parent reflect.Type, rule, param, tag string) string {
+	if parent == nil || param == "" {
+		return param
+	}
+	rename := func(name string) string {
+		if field, ok := parent.FieldByName(name); ok {
+			if n := tagName(field, tag); n != "" {
+				return n
+			}
+		}
+		return name
+	}
+	switch {
+	case fieldParamRules[rule]:
+		return rename(param)
+	case pairParamRules[rule], listParamRules[rule]:
+		tokens := strings.Fields(param)
+		for i := range tokens {
+			if listParamRules[rule] || i%2 == 0 {
+				tokens[i] = rename(tokens[i])
+			}
+		}
+		return strings.Join(tokens, " ")
+	}
+	return param
+}
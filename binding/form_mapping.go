@@ -0,0 +1,508 @@
+package binding
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType     = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// MappingError 字段映射错误，Field 为表单中的字段路径，如 user.name、items[0].id
+type MappingError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *MappingError) Error() string {
+	return fmt.Sprintf("binding: field %q: cannot parse %q: %v", e.Field, e.Value, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e *MappingError) Unwrap() error {
+	return e.Err
+}
+
+// MapForm 按指定标签将键值数据映射到结构体
+// form 标签缺省时使用字段名，其他标签只映射显式声明的字段；
+// tag 为 header 时字段名按 HTTP 头规范化，以匹配 http.Header 的键。
+// 支持嵌套与内嵌结构体（user.name、items[0].id）、指针字段、time.Time（time_format、time_utc、time_location 标签）、
+// default 标签、encoding.TextUnmarshaler 以及 key[sub] 形式的 map 字段
+func MapForm(ptr interface{}, form map[string][]string, tag string) error {
+	return mapFormByTag(ptr, form, tag)
+}
+
+// mapForm 将表单数据映射到结构体
+func mapForm(ptr interface{}, form map[string][]string) error {
+	return mapFormByTag(ptr, form, "form")
+}
+
+// mapFormByTag 按指定标签将表单数据映射到结构体
+func mapFormByTag(ptr interface{}, form map[string][]string, tag string) error {
+	return mapFormFiles(ptr, form, nil, tag)
+}
+
+// mapMultipartForm 映射 multipart 表单，*multipart.FileHeader 与 []*multipart.FileHeader 字段从上传文件中填充
+func mapMultipartForm(ptr interface{}, form *multipart.Form) error {
+	return mapFormFiles(ptr, form.Value, form.File, "form")
+}
+
+// mapFormFiles 按指定标签将表单数据与上传文件映射到结构体
+func mapFormFiles(ptr interface{}, form map[string][]string, files map[string][]*multipart.FileHeader, tag string) error {
+	return mapWith(ptr, &formMapper{form: form, files: files, tag: tag})
+}
+
+// MapDefaults 按 default 标签为结构体字段设置默认值，多来源绑定时应在映射各来源之前调用
+func MapDefaults(ptr interface{}) error {
+	return mapWith(ptr, &formMapper{tag: "form"})
+}
+
+// mapWithoutDefaults 按指定标签映射数据中存在的键，不应用 default 标签
+func mapWithoutDefaults(ptr interface{}, form map[string][]string, tag string) error {
+	return mapWith(ptr, &formMapper{form: form, tag: tag, skipDefaults: true})
+}
+
+// mapWith 使用映射器映射结构体
+func mapWith(ptr interface{}, m *formMapper) error {
+	val := reflect.ValueOf(ptr)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return errors.New("binding: target must be a non-nil pointer to struct")
+	}
+	return m.mapStruct(val.Elem(), "")
+}
+
+// formMapper 表单映射器
+type formMapper struct {
+	form         map[string][]string
+	files        map[string][]*multipart.FileHeader
+	tag          string
+	skipDefaults bool // 不应用 default 标签
+}
+
+// mapStruct 映射结构体的所有字段，prefix 为父级字段路径
+func (m *formMapper) mapStruct(val reflect.Value, prefix string) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := val.Field(i)
+
+		name := typeField.Tag.Get(m.tag)
+		if name == "-" {
+			continue
+		}
+		// 未声明标签的内嵌结构体字段提升到父级
+		if name == "" && typeField.Anonymous {
+			embedded := structField
+			if embedded.Kind() == reflect.Ptr {
+				if !embedded.CanSet() || embedded.Type().Elem().Kind() != reflect.Struct {
+					continue
+				}
+				if embedded.IsNil() {
+					embedded.Set(reflect.New(embedded.Type().Elem()))
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := m.mapStruct(embedded, prefix); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !structField.CanSet() {
+			continue
+		}
+		if name == "" {
+			if m.tag != "form" {
+				continue
+			}
+			name = typeField.Name
+		}
+		name = strings.Split(name, ",")[0]
+
+		key := prefix + name
+		if m.tag == "header" {
+			key = textproto.CanonicalMIMEHeaderKey(key)
+		}
+		if err := m.mapField(structField, typeField, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapField 映射单个字段
+func (m *formMapper) mapField(value reflect.Value, field reflect.StructField, key string) error {
+	switch value.Type() {
+	case fileHeaderType:
+		if files := m.files[key]; len(files) > 0 {
+			value.Set(reflect.ValueOf(files[0]))
+		}
+		return nil
+	case fileHeadersType:
+		if files := m.files[key]; len(files) > 0 {
+			value.Set(reflect.ValueOf(files))
+		}
+		return nil
+	}
+
+	form := m.form
+	values, exists := form[key]
+	if !exists {
+		values, exists = form[key+"[]"]
+	}
+	if !exists && !m.skipDefaults {
+		if def, ok := field.Tag.Lookup("default"); ok && !hasPrefixKey(form, key) {
+			if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+				values = strings.Split(def, ",")
+			} else {
+				values = []string{def}
+			}
+			exists = true
+		}
+	}
+
+	typ := value.Type()
+	if typ.Kind() == reflect.Ptr && !isScalarType(typ) {
+		if !exists && !hasPrefixKey(form, key) {
+			return nil
+		}
+		elem := reflect.New(typ.Elem())
+		if err := m.mapField(elem.Elem(), field, key); err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+	}
+
+	switch {
+	case isScalarType(typ):
+		if !exists || len(values) == 0 {
+			return nil
+		}
+		return setValue(value, field, key, values[0])
+	case typ.Kind() == reflect.Struct:
+		return m.mapStruct(value, key+".")
+	case typ.Kind() == reflect.Slice:
+		if isScalarType(typ.Elem()) {
+			if !exists {
+				return nil
+			}
+			slice := reflect.MakeSlice(typ, len(values), len(values))
+			for i, v := range values {
+				if err := setValue(slice.Index(i), field, fmt.Sprintf("%s[%d]", key, i), v); err != nil {
+					return err
+				}
+			}
+			value.Set(slice)
+			return nil
+		}
+		return m.mapIndexedSlice(value, field, key)
+	case typ.Kind() == reflect.Array:
+		if !exists {
+			return nil
+		}
+		for i := 0; i < value.Len() && i < len(values); i++ {
+			if err := setValue(value.Index(i), field, fmt.Sprintf("%s[%d]", key, i), values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case typ.Kind() == reflect.Map:
+		return mapMap(value, field, form, key)
+	default:
+		if !exists {
+			return nil
+		}
+		return &MappingError{Field: key, Value: values[0], Err: fmt.Errorf("unsupported type %s", typ)}
+	}
+}
+
+// mapIndexedSlice 映射 items[0].id 形式的结构体切片
+func (m *formMapper) mapIndexedSlice(value reflect.Value, field reflect.StructField, key string) error {
+	maxIndex := -1
+	prefix := key + "["
+	for k := range m.form {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		end := strings.IndexByte(k[len(prefix):], ']')
+		if end < 0 {
+			continue
+		}
+		index, err := strconv.Atoi(k[len(prefix) : len(prefix)+end])
+		if err != nil || index < 0 {
+			continue
+		}
+		if index > maxIndex {
+			maxIndex = index
+		}
+	}
+	if maxIndex < 0 {
+		return nil
+	}
+	// 限制稀疏索引造成的内存放大
+	if maxIndex >= maxSliceIndex {
+		return &MappingError{Field: key, Value: strconv.Itoa(maxIndex), Err: errors.New("slice index out of range")}
+	}
+
+	slice := reflect.MakeSlice(value.Type(), maxIndex+1, maxIndex+1)
+	for i := 0; i <= maxIndex; i++ {
+		if err := m.mapField(slice.Index(i), field, fmt.Sprintf("%s[%d]", key, i)); err != nil {
+			return err
+		}
+	}
+	value.Set(slice)
+	return nil
+}
+
+// maxSliceIndex 结构体切片允许的最大索引
+const maxSliceIndex = 1000
+
+// mapMap 映射 key[sub] 形式的 map 字段
+func mapMap(value reflect.Value, field reflect.StructField, form map[string][]string, key string) error {
+	typ := value.Type()
+	if typ.Key().Kind() != reflect.String || !isScalarType(typ.Elem()) {
+		return nil
+	}
+	prefix := key + "["
+	var m reflect.Value
+	for k, values := range form {
+		if !strings.HasPrefix(k, prefix) || !strings.HasSuffix(k, "]") || len(values) == 0 {
+			continue
+		}
+		sub := k[len(prefix) : len(k)-1]
+		if strings.ContainsAny(sub, "[]") {
+			continue
+		}
+		elem := reflect.New(typ.Elem()).Elem()
+		if err := setValue(elem, field, k, values[0]); err != nil {
+			return err
+		}
+		if !m.IsValid() {
+			m = reflect.MakeMap(typ)
+		}
+		m.SetMapIndex(reflect.ValueOf(sub).Convert(typ.Key()), elem)
+	}
+	if m.IsValid() {
+		value.Set(m)
+	}
+	return nil
+}
+
+// setValue 将字符串解析为字段类型，失败时返回带字段路径的错误
+func setValue(value reflect.Value, field reflect.StructField, path, val string) error {
+	if value.Kind() == reflect.Ptr {
+		elem := reflect.New(value.Type().Elem())
+		if err := setValue(elem.Elem(), field, path, val); err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+	}
+
+	var err error
+	switch {
+	case value.Type() == timeType:
+		err = setTimeField(val, field, value)
+	case reflect.PointerTo(value.Type()).Implements(textUnmarshalerType):
+		err = value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val))
+	default:
+		err = setWithProperType(value.Kind(), val, value)
+	}
+	if err != nil {
+		return &MappingError{Field: path, Value: val, Err: err}
+	}
+	return nil
+}
+
+// isScalarType 是否可由单个字符串直接解析
+func isScalarType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == timeType || reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// hasPrefixKey 检查表单中是否存在以 key 为前缀的嵌套字段
+func hasPrefixKey(form map[string][]string, key string) bool {
+	for k := range form {
+		if len(k) > len(key) && strings.HasPrefix(k, key) && (k[len(key)] == '.' || k[len(key)] == '[') {
+			return true
+		}
+	}
+	return false
+}
+
+// setTimeField 按 time_format、time_utc、time_location 标签解析时间
+// time_format 支持 Go 时间布局以及 unix、unixmilli、unixmicro、unixnano，默认 RFC3339
+func setTimeField(val string, field reflect.StructField, value reflect.Value) error {
+	if val == "" {
+		value.Set(reflect.ValueOf(time.Time{}))
+		return nil
+	}
+
+	format := field.Tag.Get("time_format")
+	switch strings.ToLower(format) {
+	case "unix", "unixmilli", "unixmicro", "unixnano":
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		var t time.Time
+		switch strings.ToLower(format) {
+		case "unix":
+			t = time.Unix(n, 0)
+		case "unixmilli":
+			t = time.UnixMilli(n)
+		case "unixmicro":
+			t = time.UnixMicro(n)
+		default:
+			t = time.Unix(0, n)
+		}
+		value.Set(reflect.ValueOf(t))
+		return nil
+	case "":
+		format = time.RFC3339
+	}
+
+	loc := time.Local
+	if isUTC, _ := strconv.ParseBool(field.Tag.Get("time_utc")); isUTC {
+		loc = time.UTC
+	}
+	if name := field.Tag.Get("time_location"); name != "" {
+		l, err := time.LoadLocation(name)
+		if err != nil {
+			return err
+		}
+		loc = l
+	}
+
+	t, err := time.ParseInLocation(format, val, loc)
+	if err != nil {
+		return err
+	}
+	value.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// setWithProperType 设置适当类型的值
+func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value) error {
+	switch valueKind {
+	case reflect.Int:
+		return setIntField(val, 0, structField)
+	case reflect.Int8:
+		return setIntField(val, 8, structField)
+	case reflect.Int16:
+		return setIntField(val, 16, structField)
+	case reflect.Int32:
+		return setIntField(val, 32, structField)
+	case reflect.Int64:
+		if _, ok := structField.Interface().(time.Duration); ok {
+			return setDurationField(val, structField)
+		}
+		return setIntField(val, 64, structField)
+	case reflect.Uint:
+		return setUintField(val, 0, structField)
+	case reflect.Uint8:
+		return setUintField(val, 8, structField)
+	case reflect.Uint16:
+		return setUintField(val, 16, structField)
+	case reflect.Uint32:
+		return setUintField(val, 32, structField)
+	case reflect.Uint64:
+		return setUintField(val, 64, structField)
+	case reflect.Bool:
+		return setBoolField(val, structField)
+	case reflect.Float32:
+		return setFloatField(val, 32, structField)
+	case reflect.Float64:
+		return setFloatField(val, 64, structField)
+	case reflect.String:
+		structField.SetString(val)
+	default:
+		return errors.New("unknown type")
+	}
+	return nil
+}
+
+// setIntField 设置整型字段
+func setIntField(val string, bitSize int, field reflect.Value) error {
+	if val == "" {
+		val = "0"
+	}
+	intVal, err := strconv.ParseInt(val, 10, bitSize)
+	if err == nil {
+		field.SetInt(intVal)
+	}
+	return err
+}
+
+// setDurationField 设置时间间隔字段
+func setDurationField(val string, field reflect.Value) error {
+	if val == "" {
+		val = "0"
+	}
+	d, err := time.ParseDuration(val)
+	if err == nil {
+		field.SetInt(int64(d))
+	}
+	return err
+}
+
+// setUintField 设置无符号整型字段
+func setUintField(val string, bitSize int, field reflect.Value) error {
+	if val == "" {
+		val = "0"
+	}
+	uintVal, err := strconv.ParseUint(val, 10, bitSize)
+	if err == nil {
+		field.SetUint(uintVal)
+	}
+	return err
+}
+
+// setBoolField 设置布尔字段
+func setBoolField(val string, field reflect.Value) error {
+	if val == "" {
+		val = "false"
+	}
+	boolVal, err := strconv.ParseBool(val)
+	if err == nil {
+		field.SetBool(boolVal)
+	}
+	return err
+}
+
+// setFloatField 设置浮点数字段
+func setFloatField(val string, bitSize int, field reflect.Value) error {
+	if val == "" {
+		val = "0.0"
+	}
+	floatVal, err := strconv.ParseFloat(val, bitSize)
+	if err == nil {
+		field.SetFloat(floatVal)
+	}
+	return err
+}
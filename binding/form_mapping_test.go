@@ -0,0 +1,96 @@
+package binding
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type mappingAddress struct {
+	City string `form:"city"`
+	Zip  *int   `form:"zip"`
+}
+
+type mappingItem struct {
+	ID   int    `form:"id"`
+	Name string `form:"name"`
+}
+
+type mappingBase struct {
+	Page int `form:"page" default:"1"`
+}
+
+type mappingRequest struct {
+	mappingBase
+	Name     string            `form:"name"`
+	Tags     []string          `form:"tags" default:"a,b"`
+	Address  mappingAddress    `form:"address"`
+	Home     *mappingAddress   `form:"home"`
+	Items    []mappingItem     `form:"items"`
+	Meta     map[string]string `form:"meta"`
+	Born     time.Time         `form:"born" time_format:"2006-01-02" time_utc:"true"`
+	Seen     time.Time         `form:"seen" time_format:"unix"`
+	Timeout  time.Duration     `form:"timeout"`
+	Age      *int              `form:"age"`
+	Optional *string           `form:"optional"`
+}
+
+// 测试嵌套结构体、指针、时间、默认值与 map 的映射
+func TestMapFormNested(t *testing.T) {
+	form := map[string][]string{
+		"name":          {"alice"},
+		"address.city":  {"Hangzhou"},
+		"address.zip":   {"310000"},
+		"items[0].id":   {"1"},
+		"items[1].id":   {"2"},
+		"items[1].name": {"pen"},
+		"meta[color]":   {"red"},
+		"born":          {"2000-01-02"},
+		"seen":          {"1700000000"},
+		"timeout":       {"1500ms"},
+		"age":           {"18"},
+	}
+	var req mappingRequest
+	if err := MapForm(&req, form, "form"); err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Page != 1 || len(req.Tags) != 2 || req.Tags[1] != "b" {
+		t.Fatalf("defaults not applied: page=%d tags=%v", req.Page, req.Tags)
+	}
+	if req.Address.City != "Hangzhou" || req.Address.Zip == nil || *req.Address.Zip != 310000 {
+		t.Fatalf("nested struct = %+v", req.Address)
+	}
+	if req.Home != nil {
+		t.Fatal("absent pointer struct should stay nil")
+	}
+	if len(req.Items) != 2 || req.Items[0].ID != 1 || req.Items[1].Name != "pen" {
+		t.Fatalf("items = %+v", req.Items)
+	}
+	if req.Meta["color"] != "red" {
+		t.Fatalf("meta = %v", req.Meta)
+	}
+	if !req.Born.Equal(time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)) || req.Seen.Unix() != 1700000000 {
+		t.Fatalf("born = %v seen = %v", req.Born, req.Seen)
+	}
+	if req.Timeout != 1500*time.Millisecond {
+		t.Fatalf("timeout = %v", req.Timeout)
+	}
+	if req.Age == nil || *req.Age != 18 || req.Optional != nil {
+		t.Fatalf("age = %v optional = %v", req.Age, req.Optional)
+	}
+}
+
+// 测试解析失败时返回带字段路径的 MappingError
+func TestMapFormMappingError(t *testing.T) {
+	var req mappingRequest
+	err := MapForm(&req, map[string][]string{"items[0].id": {"x"}}, "form")
+
+	var mappingErr *MappingError
+	if !errors.As(err, &mappingErr) {
+		t.Fatalf("err = %v, want *MappingError", err)
+	}
+	if mappingErr.Field != "items[0].id" || mappingErr.Value != "x" {
+		t.Fatalf("field = %q value = %q", mappingErr.Field, mappingErr.Value)
+	}
+}
@@ -0,0 +1,26 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+)
+
+// groupsKey 校验分组在 context 中的键
+type groupsKey struct{}
+
+// WithGroups 返回携带校验分组的 context，绑定器校验请求时只启用这些分组
+func WithGroups(ctx context.Context, groups ...string) context.Context {
+	return context.WithValue(ctx, groupsKey{}, groups)
+}
+
+// GroupsFromContext 获取 context 中的校验分组
+func GroupsFromContext(ctx context.Context) []string {
+	groups, _ := ctx.Value(groupsKey{}).([]string)
+	return groups
+}
+
+// validateRequest 使用请求 context 并按其中的校验分组校验结构体
+func validateRequest(req *http.Request, obj interface{}, tag string) error {
+	ctx := req.Context()
+	return ValidateCtx(ctx, obj, tag, GroupsFromContext(ctx)...)
+}
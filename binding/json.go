@@ -0,0 +1,166 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONOptions JSON 绑定选项，零值与标准库默认行为一致
+type JSONOptions struct {
+	DisallowUnknownFields bool // 拒绝结构体中不存在的字段
+	UseNumber             bool // 数字解码为 json.Number，避免大整数精度丢失
+	DisallowTrailingData  bool // 拒绝 JSON 值之后的多余数据
+	MaxDepth              int  // 最大嵌套层级，0 表示不限制
+	MaxArrayLength        int  // 单个数组的最大元素数，0 表示不限制
+}
+
+// decodeJSON 按选项解码 JSON 请求体
+func decodeJSON(r io.Reader, opts *JSONOptions, obj interface{}) error {
+	if opts == nil {
+		return decodeError(json.NewDecoder(r).Decode(obj))
+	}
+
+	if opts.MaxDepth > 0 || opts.MaxArrayLength > 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if err := checkJSONLimits(data, opts.MaxDepth, opts.MaxArrayLength); err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	decoder := json.NewDecoder(r)
+	if opts.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if opts.UseNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(obj); err != nil {
+		if name, ok := unknownField(err); ok {
+			return &Error{Fields: []FieldError{newFieldError(name, "unknown", "")}, err: err}
+		}
+		return decodeError(err)
+	}
+	if opts.DisallowTrailingData {
+		if _, err := decoder.Token(); err != io.EOF {
+			err = errors.New("json: trailing data after top-level value")
+			return &Error{Fields: []FieldError{newFieldError("", "trailing", "")}, err: err}
+		}
+	}
+	return nil
+}
+
+// unknownField 从标准库错误中解析未知字段名
+func unknownField(err error) (string, bool) {
+	const prefix = "json: unknown field "
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return "", false
+	}
+	name, uerr := strconv.Unquote(strings.TrimPrefix(msg, prefix))
+	if uerr != nil {
+		return "", false
+	}
+	return name, true
+}
+
+// jsonFrame 扫描 JSON 时的容器状态
+type jsonFrame struct {
+	array     bool
+	key       string
+	expectKey bool
+	count     int
+}
+
+// checkJSONLimits 扫描 JSON 文本检查嵌套层级与数组长度，不校验语法，语法错误交由解码器报告
+func checkJSONLimits(data []byte, maxDepth, maxArrayLength int) error {
+	stack := make([]jsonFrame, 0, 8)
+
+	// element 在数组中遇到首个元素时计数
+	element := func() {
+		if n := len(stack); n > 0 && stack[n-1].array && stack[n-1].count == 0 {
+			stack[n-1].count = 1
+		}
+	}
+
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; c {
+		case ' ', '\t', '\r', '\n', ':':
+		case '"':
+			start := i + 1
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if n := len(stack); n > 0 && !stack[n-1].array && stack[n-1].expectKey {
+				end := i
+				if end > len(data) {
+					end = len(data)
+				}
+				stack[n-1].key = string(data[start:end])
+				stack[n-1].expectKey = false
+				continue
+			}
+			element()
+		case '{', '[':
+			element()
+			if maxDepth > 0 && len(stack)+1 > maxDepth {
+				path := jsonPath(stack)
+				return &Error{
+					Fields: []FieldError{newFieldError(path, "max_depth", strconv.Itoa(maxDepth))},
+					err:    fmt.Errorf("json: nesting depth exceeds %d", maxDepth),
+				}
+			}
+			stack = append(stack, jsonFrame{array: c == '[', expectKey: c == '{'})
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case ',':
+			n := len(stack)
+			if n == 0 {
+				continue
+			}
+			if !stack[n-1].array {
+				stack[n-1].expectKey = true
+				continue
+			}
+			stack[n-1].count++
+			if maxArrayLength > 0 && stack[n-1].count > maxArrayLength {
+				path := jsonPath(stack[:n-1])
+				return &Error{
+					Fields: []FieldError{newFieldError(path, "max_array_length", strconv.Itoa(maxArrayLength))},
+					err:    fmt.Errorf("json: array length exceeds %d", maxArrayLength),
+				}
+			}
+		default:
+			element()
+		}
+	}
+	return nil
+}
+
+// jsonPath 将扫描状态转换为 items[0].id 形式的字段路径
+func jsonPath(stack []jsonFrame) string {
+	var b strings.Builder
+	for _, f := range stack {
+		if f.array {
+			b.WriteString("[" + strconv.Itoa(f.count-1) + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(f.key)
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// 测试严格 JSON 绑定选项
+func TestJSONOptions(t *testing.T) {
+	opts := &JSONOptions{
+		DisallowUnknownFields: true,
+		DisallowTrailingData:  true,
+		MaxDepth:              4,
+		MaxArrayLength:        2,
+	}
+	type item struct {
+		ID   int         `json:"id"`
+		Tags []string    `json:"tags"`
+		Meta interface{} `json:"meta"`
+	}
+	type request struct {
+		Items []item `json:"items"`
+	}
+
+	tests := []struct {
+		body      string
+		wantField string
+		wantRule  string
+	}{
+		{`{"items":[{"id":1,"tags":["a","b"]}]}`, "", ""},
+		{`{"items":[{"id":1,"extra":true}]}`, "extra", "unknown"},
+		{`{"items":[]} {}`, "", "trailing"},
+		{`{"items":[{"id":1,"meta":{"a":{"b":1}}}]}`, "items[0].meta.a", "max_depth"},
+		{`{"items":[{"id":1,"tags":["a","b","c"]}]}`, "items[0].tags", "max_array_length"},
+		{`{"items":[{"id":1,"tags":["a,b","[c"]}]}`, "", ""},
+	}
+	for _, tt := range tests {
+		var req request
+		err := JSONBinding{Options: opts}.Bind(httptest.NewRequest("POST", "/", strings.NewReader(tt.body)), &req)
+		if tt.wantRule == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error %v", tt.body, err)
+			}
+			continue
+		}
+		var bindErr *Error
+		if !errors.As(err, &bindErr) || bindErr.Fields[0].Field != tt.wantField || bindErr.Fields[0].Rule != tt.wantRule {
+			t.Fatalf("%s: err = %v, want %s on %q", tt.body, err, tt.wantRule, tt.wantField)
+		}
+	}
+
+	var v map[string]interface{}
+	err := JSONBinding{Options: &JSONOptions{UseNumber: true}}.Bind(httptest.NewRequest("POST", "/", strings.NewReader(`{"id":9007199254740993}`)), &v)
+	if err != nil || v["id"] != json.Number("9007199254740993") {
+		t.Fatalf("UseNumber: v = %v err = %v", v, err)
+	}
+}
@@ -0,0 +1,32 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ugorji/go/codec"
+)
+
+// msgpackHandle MessagePack 编解码配置，配置完成后可并发使用
+var msgpackHandle = &codec.MsgpackHandle{}
+
+// MsgPackBinding MessagePack 绑定，字段名使用 codec 或 json 标签
+type MsgPackBinding struct{}
+
+func (MsgPackBinding) Name() string {
+	return "msgpack"
+}
+
+func (b MsgPackBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "json")
+}
+
+func (MsgPackBinding) Decode(req *http.Request, obj interface{}) error {
+	if req.Body == nil {
+		return errors.New("invalid request")
+	}
+	return codec.NewDecoder(req.Body, msgpackHandle).Decode(obj)
+}
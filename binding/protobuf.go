@@ -0,0 +1,39 @@
+package binding
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoBufBinding Protobuf 绑定，目标必须实现 proto.Message
+type ProtoBufBinding struct{}
+
+func (ProtoBufBinding) Name() string {
+	return "protobuf"
+}
+
+// Bind 解码后按 json 标签命名字段路径执行校验
+func (b ProtoBufBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "json")
+}
+
+func (ProtoBufBinding) Decode(req *http.Request, obj interface{}) error {
+	if req.Body == nil {
+		return errors.New("invalid request")
+	}
+	msg, ok := obj.(proto.Message)
+	if !ok {
+		return errors.New("binding: protobuf target must implement proto.Message")
+	}
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(buf, msg)
+}
@@ -0,0 +1,66 @@
+package binding
+
+import (
+	"mime"
+	"strings"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Binding{
+		"application/json":                  JSON,
+		"application/xml":                   XML,
+		"text/xml":                          XML,
+		"application/x-www-form-urlencoded": FormPost,
+		"multipart/form-data":               FormMultipart,
+		"application/x-yaml":                YAML,
+		"application/yaml":                  YAML,
+		"text/yaml":                         YAML,
+		"application/toml":                  TOML,
+		"application/x-protobuf":            ProtoBuf,
+		"application/protobuf":              ProtoBuf,
+		"application/msgpack":               MsgPack,
+		"application/x-msgpack":             MsgPack,
+	}
+)
+
+// Register 注册内容类型对应的绑定器，已注册的类型会被覆盖
+// contentType 为不含参数的媒体类型，如 application/vnd.acme+binary
+func Register(contentType string, b Binding) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(strings.TrimSpace(contentType))] = b
+}
+
+// Lookup 按 Content-Type 请求头查找绑定器，忽略 charset 等参数
+// 未注册的 +json、+xml 结构化后缀类型分别使用 JSON、XML 绑定器
+func Lookup(contentType string) (Binding, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+
+	registryMu.RLock()
+	b, ok := registry[mediaType]
+	registryMu.RUnlock()
+	if ok {
+		return b, true
+	}
+
+	switch {
+	case strings.HasSuffix(mediaType, "+json"):
+		return JSON, true
+	case strings.HasSuffix(mediaType, "+xml"):
+		return XML, true
+	}
+	return nil, false
+}
+
+// Default 按 Content-Type 请求头选择绑定器，未注册的类型使用 Form 绑定
+func Default(contentType string) Binding {
+	if b, ok := Lookup(contentType); ok {
+		return b
+	}
+	return Form
+}
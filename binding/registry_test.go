@@ -0,0 +1,80 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type formatRequest struct {
+	Name string `json:"name" yaml:"name" toml:"name" validate:"required"`
+	Age  int    `json:"age" yaml:"age" toml:"age"`
+}
+
+type upperBinding struct{}
+
+func (upperBinding) Name() string { return "upper" }
+
+func (upperBinding) Bind(req *http.Request, obj interface{}) error {
+	obj.(*formatRequest).Name = "UPPER"
+	return nil
+}
+
+// 测试按 Content-Type 选择各格式绑定器以及自定义注册
+func TestLookupBindings(t *testing.T) {
+	var msgpack bytes.Buffer
+	codec.NewEncoder(&msgpack, msgpackHandle).Encode(map[string]interface{}{"name": "alice", "age": 30})
+
+	Register("application/vnd.acme.upper", upperBinding{})
+
+	tests := []struct {
+		ctype string
+		body  []byte
+		want  formatRequest
+	}{
+		{"application/x-yaml", []byte("name: alice\nage: 30\n"), formatRequest{"alice", 30}},
+		{"application/toml; charset=utf-8", []byte("name = \"alice\"\nage = 30\n"), formatRequest{"alice", 30}},
+		{"application/msgpack", msgpack.Bytes(), formatRequest{"alice", 30}},
+		{"application/problem+json", []byte(`{"name":"alice","age":30}`), formatRequest{"alice", 30}},
+		{"application/vnd.acme.upper", nil, formatRequest{Name: "UPPER"}},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/", bytes.NewReader(tt.body))
+		var got formatRequest
+		if err := Default(tt.ctype).Bind(r, &got); err != nil {
+			t.Fatalf("%s: %v", tt.ctype, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%s: got %+v, want %+v", tt.ctype, got, tt.want)
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/", bytes.NewReader([]byte("age: 1\n")))
+	var bindErr *Error
+	if err := Default("text/yaml").Bind(r, &formatRequest{}); !errors.As(err, &bindErr) || bindErr.Fields[0].Field != "name" {
+		t.Fatalf("yaml validation err = %v", err)
+	}
+}
+
+// 测试 Protobuf 绑定
+func TestProtoBufBinding(t *testing.T) {
+	buf, _ := proto.Marshal(wrapperspb.String("alice"))
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(buf))
+
+	var msg wrapperspb.StringValue
+	if err := Default("application/x-protobuf").Bind(r, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.GetValue() != "alice" {
+		t.Fatalf("value = %q, want alice", msg.GetValue())
+	}
+	if err := ProtoBuf.Bind(httptest.NewRequest("POST", "/", bytes.NewReader(buf)), &formatRequest{}); err == nil {
+		t.Fatal("non proto.Message target should fail")
+	}
+}
@@ -0,0 +1,29 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// TOMLBinding TOML 绑定
+type TOMLBinding struct{}
+
+func (TOMLBinding) Name() string {
+	return "toml"
+}
+
+func (b TOMLBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "toml")
+}
+
+func (TOMLBinding) Decode(req *http.Request, obj interface{}) error {
+	if req.Body == nil {
+		return errors.New("invalid request")
+	}
+	return toml.NewDecoder(req.Body).Decode(obj)
+}
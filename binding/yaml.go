@@ -0,0 +1,29 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLBinding YAML 绑定
+type YAMLBinding struct{}
+
+func (YAMLBinding) Name() string {
+	return "yaml"
+}
+
+func (b YAMLBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := b.Decode(req, obj); err != nil {
+		return err
+	}
+	return validateRequest(req, obj, "yaml")
+}
+
+func (YAMLBinding) Decode(req *http.Request, obj interface{}) error {
+	if req.Body == nil {
+		return errors.New("invalid request")
+	}
+	return yaml.NewDecoder(req.Body).Decode(obj)
+}
@@ -0,0 +1,202 @@
+package nova
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	novaerrors "github.com/xzl-go/nova/errors"
+)
+
+const (
+	// DefaultMaxDecompressionRatio 默认解压比例上限
+	DefaultMaxDecompressionRatio = 100
+	// minRatioCheckBytes 解压数据超过该大小后才检查解压比例，避免小请求体高压缩率误判
+	minRatioCheckBytes = 64 << 10
+)
+
+// BodyLimit 设置当前路由的请求体大小上限，覆盖 Engine.MaxBodyBytes，需在读取请求体之前执行
+func BodyLimit(n int64) HandlerFunc {
+	return func(c *Context) {
+		c.SetMaxBodyBytes(n)
+		c.Next()
+	}
+}
+
+// SetMaxBodyBytes 设置当前请求的请求体大小上限，小于等于 0 表示不限制，需在读取请求体之前调用
+func (c *Context) SetMaxBodyBytes(n int64) {
+	c.maxBodyBytes = n
+	if n == 0 {
+		c.maxBodyBytes = -1
+	}
+}
+
+// bodyLimit 获取当前请求生效的请求体大小上限，0 表示不限制
+func (c *Context) bodyLimit() int64 {
+	switch {
+	case c.maxBodyBytes < 0:
+		return 0
+	case c.maxBodyBytes > 0:
+		return c.maxBodyBytes
+	case c.engine != nil:
+		return c.engine.MaxBodyBytes
+	default:
+		return 0
+	}
+}
+
+// wrapRequestBody 包装请求体，在首次读取时应用大小限制与解压
+func (e *Engine) wrapRequestBody(c *Context) {
+	r := c.Request
+	if r.Body == nil || r.Body == http.NoBody {
+		return
+	}
+	body := &requestBody{c: c, raw: r.Body}
+
+	if e.DecompressRequestBody {
+		switch encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))); encoding {
+		case "gzip", "x-gzip", "deflate":
+			body.encoding = encoding
+			// 解压后长度未知，处理函数看到的是解码后的请求体
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+		}
+	}
+	r.Body = body
+}
+
+// requestBody 限制大小并透明解压的请求体
+type requestBody struct {
+	c        *Context
+	raw      io.ReadCloser
+	encoding string
+	reader   io.Reader
+	decoder  io.Closer
+	err      error
+}
+
+func (b *requestBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.reader == nil {
+		if err := b.init(); err != nil {
+			return 0, b.fail(err)
+		}
+	}
+	n, err := b.reader.Read(p)
+	if err != nil && err != io.EOF {
+		return n, b.fail(err)
+	}
+	return n, err
+}
+
+func (b *requestBody) Close() error {
+	if b.decoder != nil {
+		b.decoder.Close()
+	}
+	return b.raw.Close()
+}
+
+// init 按首次读取时生效的配置构造读取链
+func (b *requestBody) init() error {
+	limit := b.c.bodyLimit()
+	var r io.Reader = b.raw
+	if limit > 0 {
+		r = http.MaxBytesReader(b.c.Writer, b.raw, limit)
+	}
+	if b.encoding == "" {
+		b.reader = r
+		return nil
+	}
+
+	counter := &countingReader{r: r}
+	var decoder io.ReadCloser
+	var err error
+	switch b.encoding {
+	case "gzip", "x-gzip":
+		decoder, err = gzip.NewReader(counter)
+	case "deflate":
+		decoder, err = zlib.NewReader(counter)
+	}
+	if err != nil {
+		return err
+	}
+	b.decoder = decoder
+
+	ratio := int64(DefaultMaxDecompressionRatio)
+	if b.c.engine != nil {
+		ratio = b.c.engine.MaxDecompressionRatio
+	}
+	b.reader = &ratioLimitedReader{r: decoder, compressed: counter, ratio: ratio}
+	if limit > 0 {
+		// 解压后的数据同样受大小上限约束
+		b.reader = &decodedLimitReader{r: b.reader, remaining: limit, limit: limit}
+	}
+	return nil
+}
+
+// fail 记录读取错误，超出大小限制时登记 413 错误供 ErrorHandler 渲染
+func (b *requestBody) fail(err error) error {
+	b.err = err
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		b.c.Error(novaerrors.Wrap(err, novaerrors.ErrBodyTooLarge, novaerrors.GetMessage(novaerrors.ErrBodyTooLarge)))
+	}
+	return err
+}
+
+// countingReader 统计已读取的字节数
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// decodedLimitReader 限制解压后的请求体大小
+type decodedLimitReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (r *decodedLimitReader) Read(p []byte) (int, error) {
+	// 多读取一个字节以判断是否超出上限
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.r.Read(p)
+	if int64(n) > r.remaining {
+		n = int(r.remaining)
+		r.remaining = 0
+		return n, &http.MaxBytesError{Limit: r.limit}
+	}
+	r.remaining -= int64(n)
+	return n, err
+}
+
+// ratioLimitedReader 限制解压比例，防止解压炸弹
+type ratioLimitedReader struct {
+	r          io.Reader
+	compressed *countingReader
+	ratio      int64
+	n          int64
+}
+
+func (r *ratioLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.ratio > 0 && r.n > minRatioCheckBytes && r.n > r.compressed.n*r.ratio {
+		return n, &http.MaxBytesError{Limit: r.compressed.n * r.ratio}
+	}
+	return n, err
+}
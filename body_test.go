@@ -0,0 +1,75 @@
+package nova
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// 测试请求体大小限制、透明解压与解压炸弹防护
+func TestRequestBody(t *testing.T) {
+	compress := func(encoding string, data []byte) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		if encoding == "deflate" {
+			w = zlib.NewWriter(&buf)
+		} else {
+			w = gzip.NewWriter(&buf)
+		}
+		w.Write(data)
+		w.Close()
+		return buf.Bytes()
+	}
+	bomb := bytes.Repeat([]byte{0}, 1<<20)
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		encoding string
+		body     []byte
+		wantCode int
+		wantBody string
+	}{
+		{name: "plain", body: []byte("hello"), wantCode: 200, wantBody: "hello"},
+		{name: "too large", maxBytes: 4, body: []byte("hello"), wantCode: 413, wantBody: `"code":2005`},
+		{name: "gzip", encoding: "gzip", body: compress("gzip", []byte("hello gzip")), wantCode: 200, wantBody: "hello gzip"},
+		{name: "deflate", encoding: "deflate", body: compress("deflate", []byte("hello deflate")), wantCode: 200, wantBody: "hello deflate"},
+		{name: "unsupported encoding", encoding: "br", body: []byte("raw"), wantCode: 200, wantBody: "raw br"},
+		{name: "decoded too large", maxBytes: 1000, encoding: "gzip", body: compress("gzip", bytes.Repeat([]byte("a"), 5000)), wantCode: 413, wantBody: `"code":2005`},
+		{name: "bomb", encoding: "gzip", body: compress("gzip", bomb), wantCode: 413, wantBody: `"code":2005`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEngine()
+			e.MaxBodyBytes = tt.maxBytes
+			e.POST("/", func(c *Context) {
+				data, err := io.ReadAll(c.Request.Body)
+				if err != nil {
+					return
+				}
+				if enc := c.Request.Header.Get("Content-Encoding"); enc != "" {
+					c.String(http.StatusOK, "%s %s", data, enc)
+					return
+				}
+				c.String(http.StatusOK, "%s", data)
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				r.Header.Set("Content-Encoding", tt.encoding)
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("got %d %s, want %d containing %s", w.Code, w.Body.String(), tt.wantCode, tt.wantBody)
+			}
+		})
+	}
+}
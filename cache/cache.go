@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -35,7 +36,7 @@ type Redis struct {
 func NewRedis(config *Config) *Redis {
 	return &Redis{
 		client: redis.NewClient(&redis.Options{
-			Addr:     config.Host + ":" + string(config.Port),
+			Addr:     config.Host + ":" + strconv.Itoa(config.Port),
 			Password: config.Password,
 			DB:       config.DB,
 		}),
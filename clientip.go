@@ -0,0 +1,137 @@
+package nova
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// 常见平台提供的客户端 IP 请求头
+const (
+	PlatformCloudflare      = "CF-Connecting-IP"
+	PlatformGoogleAppEngine = "X-Appengine-Remote-Addr"
+	PlatformFlyIO           = "Fly-Client-IP"
+)
+
+// defaultRemoteIPHeaders 默认从受信任代理读取客户端 IP 的请求头
+var defaultRemoteIPHeaders = []string{"Forwarded", "X-Forwarded-For", "X-Real-IP"}
+
+// SetTrustedProxies 设置受信任的代理，支持 CIDR 与单个 IP
+// 只有直连对端属于受信任代理时才会解析转发请求头，传入 nil 表示不信任任何代理
+func (e *Engine) SetTrustedProxies(proxies []string) error {
+	cidrs := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("nova: invalid trusted proxy %q", proxy)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, cidr, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("nova: invalid trusted proxy %q: %v", proxy, err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	e.trustedCIDRs = cidrs
+	return nil
+}
+
+// isTrustedProxy 检查 IP 是否属于受信任代理
+func (e *Engine) isTrustedProxy(ip net.IP) bool {
+	for _, cidr := range e.trustedCIDRs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoteIP 获取直连对端的 IP（不含端口）
+func (c *Context) RemoteIP() string {
+	ip := net.ParseIP(stripPort(strings.TrimSpace(c.Request.RemoteAddr)))
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// ClientIP 获取客户端IP
+// 优先使用 Engine.TrustedPlatform 指定的平台请求头；直连对端为受信任代理时，
+// 按 Engine.RemoteIPHeaders 顺序从右向左解析转发链，返回第一个不受信任的地址；否则返回直连对端 IP
+func (c *Context) ClientIP() string {
+	c.checkReleased()
+	e := c.engine
+	if e != nil && e.TrustedPlatform != "" {
+		if ip := net.ParseIP(strings.TrimSpace(c.Request.Header.Get(e.TrustedPlatform))); ip != nil {
+			return ip.String()
+		}
+	}
+
+	remoteIP := c.RemoteIP()
+	if e == nil || remoteIP == "" || !e.isTrustedProxy(net.ParseIP(remoteIP)) {
+		return remoteIP
+	}
+
+	headers := e.RemoteIPHeaders
+	if headers == nil {
+		headers = defaultRemoteIPHeaders
+	}
+	for _, name := range headers {
+		values := c.Request.Header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		var chain []string
+		switch http.CanonicalHeaderKey(name) {
+		case "Forwarded":
+			chain = parseForwarded(values)
+		default:
+			for _, value := range values {
+				chain = append(chain, strings.Split(value, ",")...)
+			}
+		}
+		if ip, ok := e.clientIPFromChain(chain); ok {
+			return ip
+		}
+	}
+	return remoteIP
+}
+
+// clientIPFromChain 从右向左遍历转发链，跳过受信任代理
+func (e *Engine) clientIPFromChain(chain []string) (string, bool) {
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip := net.ParseIP(stripPort(strings.TrimSpace(chain[i])))
+		if ip == nil {
+			return "", false
+		}
+		if i == 0 || !e.isTrustedProxy(ip) {
+			return ip.String(), true
+		}
+	}
+	return "", false
+}
+
+// parseForwarded 按 RFC 7239 解析 Forwarded 请求头中的 for 参数
+func parseForwarded(values []string) []string {
+	var chain []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok || !strings.EqualFold(key, "for") {
+					continue
+				}
+				// 未知或混淆的节点标识（unknown、_hidden）在解析时视为无效地址
+				chain = append(chain, strings.Trim(strings.TrimSpace(val), `"`))
+			}
+		}
+	}
+	return chain
+}
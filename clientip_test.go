@@ -0,0 +1,76 @@
+package nova
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+// 测试受信任代理下的客户端 IP 解析
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   string
+		headers  map[string]string
+		platform string
+		want     string
+	}{
+		{
+			name:    "untrusted peer ignores headers",
+			remote:  "203.0.113.9:5000",
+			headers: map[string]string{"X-Forwarded-For": "1.1.1.1", "X-Real-IP": "2.2.2.2"},
+			want:    "203.0.113.9",
+		},
+		{
+			name:    "x-forwarded-for right to left",
+			remote:  "10.0.0.1:5000",
+			headers: map[string]string{"X-Forwarded-For": "6.6.6.6, 198.51.100.7, 10.0.0.2"},
+			want:    "198.51.100.7",
+		},
+		{
+			name:    "forwarded header",
+			remote:  "10.0.0.1:5000",
+			headers: map[string]string{"Forwarded": `for=192.0.2.60;proto=http, for="[2001:db8::1]:4711"`},
+			want:    "2001:db8::1",
+		},
+		{
+			name:    "all hops trusted returns leftmost",
+			remote:  "10.0.0.1:5000",
+			headers: map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"},
+			want:    "10.0.0.3",
+		},
+		{
+			name:    "invalid chain falls back to remote",
+			remote:  "10.0.0.1:5000",
+			headers: map[string]string{"X-Forwarded-For": "garbage"},
+			want:    "10.0.0.1",
+		},
+		{
+			name:     "trusted platform header",
+			remote:   "203.0.113.9:5000",
+			headers:  map[string]string{PlatformCloudflare: "198.51.100.1"},
+			platform: PlatformCloudflare,
+			want:     "198.51.100.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEngine()
+			if err := e.SetTrustedProxies([]string{"10.0.0.0/8"}); err != nil {
+				t.Fatal(err)
+			}
+			e.TrustedPlatform = tt.platform
+
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remote
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			c := NewContext(httptest.NewRecorder(), r)
+			c.engine = e
+			if got := c.ClientIP(); got != tt.want {
+				t.Fatalf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package nova
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/xzl-go/nova/binding"
 	"net/http"
-	"strings"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,13 +29,23 @@ type Context struct {
 	Errors     []error
 	store      map[string]interface{}
 	storeMutex sync.RWMutex
+	fullPath   string
+	released   int32
+
+	maxBodyBytes int64
+	jsonOptions  *binding.JSONOptions
+	clientCtx    context.Context // 客户端连接的 context，不含 Engine 为处理函数设置的超时
 }
 
+// errCopiedContextWrite 副本上下文不允许写响应
+var errCopiedContextWrite = errors.New("nova: cannot write response from a copied context")
+
 // NewContext 创建新的上下文
 func NewContext(w http.ResponseWriter, r *http.Request) *Context {
 	return &Context{
 		Request:  r,
 		Response: w,
+		Writer:   newResponseWriter(w),
 		Params:   make(map[string]string),
 		start:    time.Now(),
 		Index:    -1,
@@ -40,23 +53,10 @@ func NewContext(w http.ResponseWriter, r *http.Request) *Context {
 	}
 }
 
-// ClientIP 获取客户端IP
-func (c *Context) ClientIP() string {
-	// 按优先级获取IP
-	ip := c.Request.Header.Get("X-Real-IP")
-	if ip != "" {
-		return ip
-	}
-	ip = c.Request.Header.Get("X-Forwarded-For")
-	if ip != "" {
-		return ip
-	}
-	return c.Request.RemoteAddr
-}
-
 // Header 设置响应头
 func (c *Context) Header(key, value string) {
-	c.Response.Header().Set(key, value)
+	c.checkReleased()
+	c.Writer.Header().Set(key, value)
 }
 
 // Status 获取响应状态码
@@ -66,9 +66,10 @@ func (c *Context) Status() int {
 
 // JSON 返回JSON响应
 func (c *Context) JSON(code int, data interface{}) {
+	c.checkReleased()
 	c.Header("Content-Type", "application/json")
-	c.Response.WriteHeader(code)
-	json.NewEncoder(c.Response).Encode(data)
+	c.Writer.WriteHeader(code)
+	json.NewEncoder(c.Writer).Encode(data)
 }
 
 // ErrorResponse 返回错误响应
@@ -89,13 +90,15 @@ func (c *Context) Fail(code int, msg string) {
 
 // String 返回字符串响应
 func (c *Context) String(code int, format string, values ...interface{}) {
+	c.checkReleased()
 	c.Header("Content-Type", "text/plain")
-	c.Response.WriteHeader(code)
-	fmt.Fprintf(c.Response, format, values...)
+	c.Writer.WriteHeader(code)
+	fmt.Fprintf(c.Writer, format, values...)
 }
 
 // Next 执行下一个中间件
 func (c *Context) Next() {
+	c.checkReleased()
 	c.Index++
 	for c.Index < len(c.handlers) {
 		c.handlers[c.Index](c)
@@ -118,6 +121,12 @@ func (c *Context) Error(err error) {
 	c.Errors = append(c.Errors, err)
 }
 
+// AbortWithError 记录错误并中断中间件链，错误由 Engine.ErrorHandler 统一渲染
+func (c *Context) AbortWithError(err error) {
+	c.Error(err)
+	c.Abort()
+}
+
 // HasError 检查是否有错误
 func (c *Context) HasError() bool {
 	return len(c.Errors) > 0
@@ -131,49 +140,118 @@ func (c *Context) GetError() error {
 	return c.Errors[len(c.Errors)-1]
 }
 
-// ResponseWriter 自定义响应写入器
-type ResponseWriter struct {
-	http.ResponseWriter
-	Status int
+// reset 重置上下文状态
+func (c *Context) reset() {
+	c.Params = make(map[string]string)
+	c.Index = -1
+	c.aborted = false
+	c.fullPath = ""
+	c.maxBodyBytes = 0
+	c.jsonOptions = nil
+	c.clientCtx = nil
+	c.Data = nil
+	c.StatusCode = 0
+	c.Errors = c.Errors[:0]
+	c.handlers = nil
+	c.engine = nil
+	c.storeMutex.Lock()
+	c.store = nil
+	c.storeMutex.Unlock()
 }
 
-// WriteHeader 重写 WriteHeader 方法
-func (w *ResponseWriter) WriteHeader(code int) {
-	w.Status = code
-	w.ResponseWriter.WriteHeader(code)
+// FullPath 获取匹配到的路由模式，如 /users/:id
+func (c *Context) FullPath() string {
+	return c.fullPath
 }
 
-// Status 获取状态码
-func (w *ResponseWriter) GetStatus() int {
-	return w.Status
+// Copy 复制一份可在 handler 返回后安全使用的只读上下文
+// 副本持有请求、路由参数、存储值与路由信息的快照，请求的 context 保留链路追踪等值但不再随请求结束而取消
+// 副本不能写响应，也不能继续执行中间件链，适合传递给后台 goroutine
+func (c *Context) Copy() *Context {
+	c.checkReleased()
+
+	cp := &Context{
+		Response:   readOnlyResponseWriter{header: make(http.Header)},
+		Params:     make(map[string]string, len(c.Params)),
+		Data:       c.Data,
+		start:      c.start,
+		engine:     c.engine,
+		StatusCode: c.StatusCode,
+		fullPath:   c.fullPath,
+	}
+	cp.Writer = newResponseWriter(cp.Response)
+	if c.Writer != nil {
+		cp.Writer.Status = c.Writer.Status
+	}
+	cp.Index = len(cp.handlers)
+
+	if c.Request != nil {
+		cp.Request = c.Request.Clone(context.WithoutCancel(c.Request.Context()))
+		cp.Request.Body = http.NoBody
+	}
+	for k, v := range c.Params {
+		cp.Params[k] = v
+	}
+	if len(c.Errors) > 0 {
+		cp.Errors = append([]error(nil), c.Errors...)
+	}
+
+	c.storeMutex.RLock()
+	if len(c.store) > 0 {
+		cp.store = make(map[string]interface{}, len(c.store))
+		for k, v := range c.store {
+			cp.store[k] = v
+		}
+	}
+	c.storeMutex.RUnlock()
+
+	return cp
 }
 
-// reset 重置上下文状态
-func (c *Context) reset() {
-	c.Params = make(map[string]string)
-	c.Index = -1
-	c.aborted = false
+// checkReleased 调试模式下检测 Context 是否在归还对象池后仍被使用
+func (c *Context) checkReleased() {
+	if atomic.LoadInt32(&c.released) == 1 {
+		panic("nova: Context used after the request finished; use c.Copy() when passing it to a goroutine")
+	}
+}
+
+// readOnlyResponseWriter 副本上下文使用的响应写入器，丢弃所有写入
+type readOnlyResponseWriter struct {
+	header http.Header
+}
+
+func (w readOnlyResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w readOnlyResponseWriter) Write([]byte) (int, error) {
+	return 0, errCopiedContextWrite
 }
 
+func (w readOnlyResponseWriter) WriteHeader(int) {}
+
 // GetParam 获取路由参数
 func (c *Context) GetParam(key string) string {
+	c.checkReleased()
 	return c.Params[key]
 }
 
 // SetParam 设置路由参数
 func (c *Context) SetParam(key, value string) {
+	c.checkReleased()
 	c.Params[key] = value
 }
 
 // ShouldBind 绑定请求参数
 func (c *Context) ShouldBind(obj interface{}) error {
+	c.checkReleased()
 	b := c.getBinding()
 	return b.Bind(c.Request, obj)
 }
 
 // ShouldBindJSON 绑定 JSON 参数
 func (c *Context) ShouldBindJSON(obj interface{}) error {
-	return binding.JSON.Bind(c.Request, obj)
+	return c.jsonBinding().Bind(c.Request, obj)
 }
 
 // ShouldBindXML 绑定 XML 参数
@@ -191,25 +269,85 @@ func (c *Context) ShouldBindForm(obj interface{}) error {
 	return binding.Form.Bind(c.Request, obj)
 }
 
+// ShouldBindUri 绑定路由参数，使用 uri 标签
+func (c *Context) ShouldBindUri(obj interface{}) error {
+	return binding.Uri.BindUriCtx(c.Request.Context(), c.uriParams(), obj)
+}
+
+// ShouldBindHeader 绑定请求头，使用 header 标签
+func (c *Context) ShouldBindHeader(obj interface{}) error {
+	return binding.Header.Bind(c.Request, obj)
+}
+
+// ShouldBindAll 从路由参数、请求头、查询参数和请求体绑定同一结构体并校验
+// 同名字段的优先级为：路由参数 > 请求头 > 查询参数 > 请求体
+func (c *Context) ShouldBindAll(obj interface{}) error {
+	if err := c.bindAll(obj); err != nil {
+		return err
+	}
+	return binding.ValidateCtx(c.Request.Context(), obj, "json", binding.GroupsFromContext(c.Request.Context())...)
+}
+
+// bindAll 依次从请求体、查询参数、请求头和路由参数填充结构体，后者覆盖前者，不执行校验
+// default 标签在映射各来源之前统一应用，只对所有来源都未提供的字段生效
+func (c *Context) bindAll(obj interface{}) error {
+	structPtr := isStructPtr(obj)
+	if structPtr {
+		if err := binding.MapDefaults(obj); err != nil {
+			return err
+		}
+	}
+	if hasBody(c.Request) {
+		if d, ok := c.getBinding().(binding.Decoder); ok {
+			if err := d.Decode(c.Request, obj); err != nil {
+				return err
+			}
+		}
+	}
+	if !structPtr {
+		return nil
+	}
+	if err := (binding.QueryBinding{SkipDefaults: true}).Decode(c.Request, obj); err != nil {
+		return err
+	}
+	if err := (binding.HeaderBinding{SkipDefaults: true}).Decode(c.Request, obj); err != nil {
+		return err
+	}
+	return binding.URIBinding{SkipDefaults: true}.DecodeUri(c.uriParams(), obj)
+}
+
+// uriParams 将路由参数转换为绑定器使用的格式
+func (c *Context) uriParams() map[string][]string {
+	params := make(map[string][]string, len(c.Params))
+	for k, v := range c.Params {
+		params[k] = []string{v}
+	}
+	return params
+}
+
+// hasBody 检查请求是否携带请求体
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+// isStructPtr 检查是否为结构体指针
+func isStructPtr(obj interface{}) bool {
+	t := reflect.TypeOf(obj)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+}
+
 // getBinding 获取绑定器
 func (c *Context) getBinding() binding.Binding {
-	contentType := c.Request.Header.Get("Content-Type")
-	switch {
-	case strings.Contains(contentType, "application/json"):
-		return binding.JSON
-	case strings.Contains(contentType, "application/xml"):
-		return binding.XML
-	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
-		return binding.FormPost
-	case strings.Contains(contentType, "multipart/form-data"):
-		return binding.FormMultipart
-	default:
-		return binding.Form
+	b := binding.Default(c.Request.Header.Get("Content-Type"))
+	if _, ok := b.(binding.JSONBinding); ok {
+		return c.jsonBinding()
 	}
+	return b
 }
 
 // Set 设置值
 func (c *Context) Set(key string, value interface{}) {
+	c.checkReleased()
 	c.storeMutex.Lock()
 	defer c.storeMutex.Unlock()
 	if c.store == nil {
@@ -220,6 +358,7 @@ func (c *Context) Set(key string, value interface{}) {
 
 // Get 获取值
 func (c *Context) Get(key string) (interface{}, bool) {
+	c.checkReleased()
 	c.storeMutex.RLock()
 	defer c.storeMutex.RUnlock()
 	value, exists := c.store[key]
@@ -0,0 +1,181 @@
+package nova
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xzl-go/nova/errors"
+)
+
+// 测试副本上下文在请求结束后仍可安全读取
+func TestContextCopy(t *testing.T) {
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+
+	var orig, cp *Context
+	e := NewEngine()
+	e.GET("/users/:id", func(c *Context) {
+		c.Set("user", "alice")
+		orig = c
+		cp = c.Copy()
+		c.String(200, "ok")
+	})
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/42", nil))
+
+	if got := cp.GetParam("id"); got != "42" {
+		t.Fatalf("param id = %q, want 42", got)
+	}
+	if v, ok := cp.Get("user"); !ok || v != "alice" {
+		t.Fatalf("store user = %v, want alice", v)
+	}
+	if got := cp.FullPath(); got != "/users/:id" {
+		t.Fatalf("full path = %q, want /users/:id", got)
+	}
+	if err := cp.Request.Context().Err(); err != nil {
+		t.Fatalf("copied request context canceled: %v", err)
+	}
+	if _, err := cp.Writer.Write([]byte("x")); err == nil {
+		t.Fatal("write on copied context should fail")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("using a released context should panic in debug mode")
+		}
+	}()
+	orig.Get("user")
+}
+
+// 测试 AbortWithError 中断处理链并由错误处理函数渲染
+func TestAbortWithError(t *testing.T) {
+	e := NewEngine()
+	called := false
+	e.GET("/items/:id", func(c *Context) {
+		c.AbortWithError(errors.New(errors.ErrNotFound, "item not found").WithDetails("id=" + c.GetParam("id")))
+	}, func(c *Context) {
+		called = true
+	})
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest("GET", "/items/7", nil))
+	if called {
+		t.Fatal("handler after AbortWithError should not run")
+	}
+	if w.Code != 409 {
+		t.Fatalf("status = %d, want 409", w.Code)
+	}
+	if body := w.Body.String(); !strings.Contains(body, `"code":4001`) || !strings.Contains(body, `"details":"id=7"`) {
+		t.Fatalf("unexpected body %s", body)
+	}
+}
+
+type uriRequest struct {
+	ID   int    `uri:"id" validate:"required,min=1"`
+	Slug string `uri:"slug" validate:"required" groups:"update"`
+}
+
+// 测试路由参数绑定、校验与校验分组
+func TestShouldBindUri(t *testing.T) {
+	e := NewEngine()
+	handler := func(c *Context) {
+		var req uriRequest
+		if err := c.ShouldBindUri(&req); err != nil {
+			c.AbortWithError(err)
+			return
+		}
+		c.String(200, "%d", req.ID)
+	}
+	e.GET("/users/:id", ValidationGroups("create"), handler)
+	e.GET("/posts/:id", handler)
+
+	tests := []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"/users/42", 200, "42"},
+		{"/users/0", 400, `"field":"id"`},
+		{"/users/abc", 400, `"code":2002`},
+		// 未激活分组时校验全部字段，slug 缺失
+		{"/posts/42", 400, `"field":"slug"`},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.wantCode || !strings.Contains(w.Body.String(), tt.wantBody) {
+			t.Errorf("%s: got %d %s, want %d containing %s", tt.path, w.Code, w.Body.String(), tt.wantCode, tt.wantBody)
+		}
+	}
+}
+
+// 测试请求头绑定按 HTTP 规范化名称匹配
+func TestShouldBindHeader(t *testing.T) {
+	type headerRequest struct {
+		RequestID string `header:"x-request-id"`
+		Tenant    string `header:"X-TENANT" validate:"required"`
+		Retries   int    `header:"x-retries"`
+	}
+
+	tests := []struct {
+		headers map[string]string
+		want    headerRequest
+		wantErr bool
+	}{
+		{map[string]string{"X-Request-Id": "abc", "x-tenant": "acme", "X-RETRIES": "3"}, headerRequest{"abc", "acme", 3}, false},
+		{map[string]string{"X-Request-Id": "abc"}, headerRequest{RequestID: "abc"}, true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		for k, v := range tt.headers {
+			r.Header.Set(k, v)
+		}
+		c := NewContext(httptest.NewRecorder(), r)
+		var got headerRequest
+		err := c.ShouldBindHeader(&got)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%v: got %+v, %v; want %+v, error %v", tt.headers, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// 测试多来源绑定的优先级：路由参数 > 请求头 > 查询参数 > 请求体
+func TestShouldBindAllPrecedence(t *testing.T) {
+	type allRequest struct {
+		Name string `json:"name" form:"name" header:"X-Name" uri:"name"`
+	}
+	e := NewEngine()
+	handler := func(c *Context) {
+		var req allRequest
+		if err := c.ShouldBindAll(&req); err != nil {
+			c.AbortWithError(err)
+			return
+		}
+		c.String(200, "%s", req.Name)
+	}
+	e.POST("/items/:name", handler)
+	e.POST("/items", handler)
+
+	tests := []struct {
+		path   string
+		header string
+		want   string
+	}{
+		{"/items/path?name=query", "header", "path"},
+		{"/items?name=query", "header", "header"},
+		{"/items?name=query", "", "query"},
+		{"/items", "", "body"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", tt.path, strings.NewReader(`{"name":"body"}`))
+		r.Header.Set("Content-Type", "application/json")
+		if tt.header != "" {
+			r.Header.Set("X-Name", tt.header)
+		}
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		if w.Code != 200 || w.Body.String() != tt.want {
+			t.Errorf("%s header=%q: got %d %s, want %s", tt.path, tt.header, w.Code, w.Body.String(), tt.want)
+		}
+	}
+}
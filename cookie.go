@@ -0,0 +1,132 @@
+package nova
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// CookieOptions Cookie 属性
+type CookieOptions struct {
+	Path     string
+	Domain   string
+	Secure   bool
+	HttpOnly bool
+	SameSite http.SameSite
+}
+
+// DefaultCookieOptions 默认 Cookie 属性
+var DefaultCookieOptions = CookieOptions{
+	Path:     "/",
+	Secure:   true,
+	HttpOnly: true,
+	SameSite: http.SameSiteLaxMode,
+}
+
+// Cookie 获取请求中的 Cookie 值
+func (c *Context) Cookie(name string) (string, error) {
+	cookie, err := c.Request.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return url.QueryUnescape(cookie.Value)
+}
+
+// SetCookie 使用引擎默认属性设置 Cookie，maxAge 小于 0 表示删除
+func (c *Context) SetCookie(name, value string, maxAge int) {
+	c.SetCookieWithOptions(name, value, maxAge, c.cookieOptions())
+}
+
+// SetCookieWithOptions 使用指定属性设置 Cookie
+func (c *Context) SetCookieWithOptions(name, value string, maxAge int, opts CookieOptions) {
+	c.checkReleased()
+	if opts.Path == "" {
+		opts.Path = "/"
+	}
+	// SameSite=None 必须同时设置 Secure，否则浏览器会拒绝该 Cookie
+	if opts.SameSite == http.SameSiteNoneMode {
+		opts.Secure = true
+	}
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    url.QueryEscape(value),
+		MaxAge:   maxAge,
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		Secure:   opts.Secure,
+		HttpOnly: opts.HttpOnly,
+		SameSite: opts.SameSite,
+	})
+}
+
+// SetSignedCookie 设置 HMAC 签名的 Cookie，值可被客户端读取但无法篡改
+// maxAge 同时写入签名内容，超过有效期后 SignedCookie 返回 ErrCookieExpired；maxAge 为 0 时使用 Keyring.SessionMaxAge
+func (c *Context) SetSignedCookie(name, value string, maxAge int) error {
+	keyring := c.keyring()
+	if keyring == nil {
+		return ErrNoKeyring
+	}
+	signed, err := keyring.Sign(name, value, time.Duration(maxAge)*time.Second)
+	if err != nil {
+		return err
+	}
+	c.SetCookie(name, signed, maxAge)
+	return nil
+}
+
+// SignedCookie 获取并校验签名 Cookie
+func (c *Context) SignedCookie(name string) (string, error) {
+	keyring := c.keyring()
+	if keyring == nil {
+		return "", ErrNoKeyring
+	}
+	signed, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return keyring.Verify(name, signed)
+}
+
+// SetEncryptedCookie 设置加密的 Cookie，值对客户端不可见且无法篡改
+// 有效期的处理与 SetSignedCookie 相同
+func (c *Context) SetEncryptedCookie(name, value string, maxAge int) error {
+	keyring := c.keyring()
+	if keyring == nil {
+		return ErrNoKeyring
+	}
+	encrypted, err := keyring.Encrypt(name, value, time.Duration(maxAge)*time.Second)
+	if err != nil {
+		return err
+	}
+	c.SetCookie(name, encrypted, maxAge)
+	return nil
+}
+
+// EncryptedCookie 获取并解密加密 Cookie
+func (c *Context) EncryptedCookie(name string) (string, error) {
+	keyring := c.keyring()
+	if keyring == nil {
+		return "", ErrNoKeyring
+	}
+	encrypted, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return keyring.Decrypt(name, encrypted)
+}
+
+// cookieOptions 获取引擎配置的 Cookie 默认属性
+func (c *Context) cookieOptions() CookieOptions {
+	if c.engine != nil {
+		return c.engine.CookieOptions
+	}
+	return DefaultCookieOptions
+}
+
+// keyring 获取引擎配置的密钥环
+func (c *Context) keyring() *Keyring {
+	if c.engine != nil {
+		return c.engine.Keyring
+	}
+	return nil
+}
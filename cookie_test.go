@@ -0,0 +1,107 @@
+package nova
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// 测试签名与加密 Cookie 在密钥轮换后仍可读取
+func TestSignedAndEncryptedCookie(t *testing.T) {
+	e := NewEngine()
+	e.Keyring = NewKeyring([]byte("old-secret"))
+	e.GET("/set", func(c *Context) {
+		c.SetSignedCookie("uid", "42", 3600)
+		c.SetEncryptedCookie("session", "secret-data", 3600)
+	})
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest("GET", "/set", nil))
+	cookies := w.Result().Cookies()
+	if len(cookies) != 2 {
+		t.Fatalf("got %d cookies, want 2", len(cookies))
+	}
+	if !cookies[0].HttpOnly || !cookies[0].Secure || cookies[0].SameSite != http.SameSiteLaxMode {
+		t.Fatalf("default cookie attributes not applied: %+v", cookies[0])
+	}
+
+	e.Keyring.Rotate([]byte("new-secret"), 1)
+
+	var uid, session string
+	var uidErr, sessionErr error
+	e.GET("/get", func(c *Context) {
+		uid, uidErr = c.SignedCookie("uid")
+		session, sessionErr = c.EncryptedCookie("session")
+	})
+	r := httptest.NewRequest("GET", "/get", nil)
+	for _, cookie := range cookies {
+		r.AddCookie(cookie)
+	}
+	e.ServeHTTP(httptest.NewRecorder(), r)
+	if uidErr != nil || uid != "42" {
+		t.Fatalf("signed cookie = %q, %v", uid, uidErr)
+	}
+	if sessionErr != nil || session != "secret-data" {
+		t.Fatalf("encrypted cookie = %q, %v", session, sessionErr)
+	}
+
+	tampered := httptest.NewRequest("GET", "/get", nil)
+	tampered.AddCookie(&http.Cookie{Name: "uid", Value: "NDM." + "AAAA"})
+	tampered.AddCookie(&http.Cookie{Name: "session", Value: cookies[0].Value})
+	e.ServeHTTP(httptest.NewRecorder(), tampered)
+	if uidErr != ErrInvalidCookie || sessionErr != ErrInvalidCookie {
+		t.Fatalf("tampered cookies accepted: %v, %v", uidErr, sessionErr)
+	}
+}
+
+// 测试签名与加密 Cookie 超过有效期后被拒绝
+func TestCookieExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := NewKeyring([]byte("secret"))
+	k.now = func() time.Time { return now }
+
+	tests := []struct {
+		name    string
+		maxAge  time.Duration
+		elapsed time.Duration
+		wantErr error
+	}{
+		{"valid", time.Hour, 59 * time.Minute, nil},
+		{"expired", time.Hour, time.Hour, ErrCookieExpired},
+		{"session default valid", 0, DefaultSessionMaxAge - time.Second, nil},
+		{"session default expired", 0, DefaultSessionMaxAge, ErrCookieExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			signed, _ := k.Sign("uid", "42", tt.maxAge)
+			encrypted, _ := k.Encrypt("uid", "42", tt.maxAge)
+			now = now.Add(tt.elapsed)
+
+			if v, err := k.Verify("uid", signed); err != tt.wantErr || (err == nil && v != "42") {
+				t.Errorf("verify = %q, %v, want %v", v, err, tt.wantErr)
+			}
+			if v, err := k.Decrypt("uid", encrypted); err != tt.wantErr || (err == nil && v != "42") {
+				t.Errorf("decrypt = %q, %v, want %v", v, err, tt.wantErr)
+			}
+		})
+	}
+
+	// SessionMaxAge 为 0 时会话值不过期
+	k.SessionMaxAge = 0
+	now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signed, _ := k.Sign("uid", "42", 0)
+	now = now.Add(365 * 24 * time.Hour)
+	if v, err := k.Verify("uid", signed); err != nil || v != "42" {
+		t.Errorf("session value without max age = %q, %v", v, err)
+	}
+
+	// 不含过期时间的旧格式签名被拒绝
+	legacy := base64.RawURLEncoding.EncodeToString([]byte("42")) + "." +
+		base64.RawURLEncoding.EncodeToString(computeMAC(k.keys[0].sign, "uid", "42"))
+	if _, err := k.Verify("uid", legacy); err != ErrInvalidCookie {
+		t.Errorf("legacy value err = %v, want ErrInvalidCookie", err)
+	}
+}
@@ -0,0 +1,32 @@
+package nova
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// debugMode 调试模式开关
+var debugMode int32
+
+// SetDebugMode 开启或关闭调试模式
+// 调试模式下会启用额外的运行时检查，例如检测 Context 释放后仍被使用
+func SetDebugMode(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&debugMode, 1)
+	} else {
+		atomic.StoreInt32(&debugMode, 0)
+	}
+}
+
+// IsDebugging 是否处于调试模式
+func IsDebugging() bool {
+	return atomic.LoadInt32(&debugMode) == 1
+}
+
+// debugPrint 调试模式下输出提示信息
+func debugPrint(format string, values ...interface{}) {
+	if IsDebugging() {
+		fmt.Fprintf(os.Stderr, "[NOVA-debug] "+format+"\n", values...)
+	}
+}
@@ -2,27 +2,54 @@ package nova
 
 import (
 	"context"
-	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/xzl-go/nova/binding"
 	"github.com/xzl-go/nova/tree"
-
-	"github.com/xzl-go/nova/logger"
-	"go.uber.org/zap"
+	"github.com/xzl-go/nova/validator"
 )
 
 // Engine 框架引擎
 type Engine struct {
 	router *tree.Node
 	groups []*RouterGroup
+	routes []RouteInfo
+
+	// CookieOptions SetCookie 使用的默认 Cookie 属性
+	CookieOptions CookieOptions
+	// Keyring 签名与加密 Cookie 使用的密钥环
+	Keyring *Keyring
+	// RedirectAllowedHosts Redirect 允许跳转的外部主机，支持 *.example.com 形式
+	RedirectAllowedHosts []string
+	// RemoteIPHeaders 直连对端为受信任代理时，用于解析客户端 IP 的请求头，按顺序尝试
+	RemoteIPHeaders []string
+	// TrustedPlatform 平台注入的客户端 IP 请求头，如 PlatformCloudflare，为空表示不使用
+	TrustedPlatform string
+
+	// MaxBodyBytes 请求体最大字节数，同时限制压缩数据与解压后的数据，超出时返回 413，0 表示不限制；单个路由可通过 BodyLimit 覆盖
+	MaxBodyBytes int64
+	// DecompressRequestBody 是否透明解压 Content-Encoding 为 gzip 或 deflate 的请求体
+	DecompressRequestBody bool
+	// MaxDecompressionRatio 解压后与解压前的最大大小比例，超出视为解压炸弹，0 表示不限制
+	MaxDecompressionRatio int64
+	// JSONOptions JSON 绑定选项，nil 表示使用标准库默认行为；单个路由可通过 StrictJSON 覆盖
+	JSONOptions *binding.JSONOptions
+	// ErrorHandler 处理链执行完毕后，存在错误且尚未写入响应时调用，默认为 DefaultErrorHandler
+	ErrorHandler ErrorHandlerFunc
+	// Recovery 处理链发生 panic 时的恢复配置，与 RecoveryWithConfig 中间件行为一致
+	Recovery RecoveryConfig
+
+	trustedCIDRs []*net.IPNet
 }
 
 // RouterGroup 路由组
 type RouterGroup struct {
 	prefix      string
 	middlewares []HandlerFunc
+	parent      *RouterGroup
 	engine      *Engine
 }
 
@@ -41,7 +68,13 @@ func (h *handlerAdapter) Handle(ctx interface{}) {
 // NewEngine 创建新引擎
 func NewEngine() *Engine {
 	engine := &Engine{
-		router: tree.NewNode(),
+		router:          tree.NewNode(),
+		CookieOptions:   DefaultCookieOptions,
+		RemoteIPHeaders: defaultRemoteIPHeaders,
+		ErrorHandler:    DefaultErrorHandler,
+
+		DecompressRequestBody: true,
+		MaxDecompressionRatio: DefaultMaxDecompressionRatio,
 	}
 	engine.groups = []*RouterGroup{{engine: engine}}
 	return engine
@@ -87,20 +120,24 @@ func (e *Engine) HEAD(pattern string, handlers ...HandlerFunc) {
 	e.groups[0].HEAD(pattern, handlers...)
 }
 
+// Route 注册类型化处理函数
+func (e *Engine) Route(method, pattern string, h TypedHandler, middlewares ...HandlerFunc) {
+	e.groups[0].Route(method, pattern, h, middlewares...)
+}
+
 // Group 创建路由组
 func (e *Engine) Group(prefix string) *RouterGroup {
 	return e.groups[0].Group(prefix)
 }
 
-// Group 创建路由组
+// Group 创建路由组，子组继承父组的中间件
 func (g *RouterGroup) Group(prefix string) *RouterGroup {
 	engine := g.engine
 	newGroup := &RouterGroup{
-		prefix:      g.prefix + prefix,
-		middlewares: make([]HandlerFunc, len(g.middlewares)),
-		engine:      engine,
+		prefix: g.prefix + prefix,
+		parent: g,
+		engine: engine,
 	}
-	copy(newGroup.middlewares, g.middlewares)
 	engine.groups = append(engine.groups, newGroup)
 	return newGroup
 }
@@ -115,13 +152,40 @@ func (g *RouterGroup) addRoute(method string, pattern string, handlers ...Handle
 	pattern = g.prefix + pattern
 	parts := parsePattern(pattern)
 
-	// 转换处理函数为适配器
-	adapters := make([]tree.Handler, len(handlers))
-	for i, handler := range handlers {
+	// 转换处理函数为适配器，路由组中间件排在路由处理函数之前；根组中间件在请求时合并
+	chain := append(g.groupMiddlewares(), handlers...)
+	adapters := make([]tree.Handler, len(chain))
+	for i, handler := range chain {
 		adapters[i] = &handlerAdapter{handler: handler}
 	}
 
 	g.engine.router.Insert(pattern, parts, 0, adapters)
+
+	g.engine.routes = append(g.engine.routes, RouteInfo{Method: method, Path: pattern})
+}
+
+// Route 注册类型化处理函数，middlewares 在处理函数之前执行，请求与响应类型记录到 Engine.Routes 中
+func (g *RouterGroup) Route(method, pattern string, h TypedHandler, middlewares ...HandlerFunc) {
+	g.addRoute(method, pattern, append(middlewares[:len(middlewares):len(middlewares)], h.Handler)...)
+	info := &g.engine.routes[len(g.engine.routes)-1]
+	info.Request = h.Request
+	info.Response = h.Response
+}
+
+// groupMiddlewares 按从外到内的顺序收集路由组及其父组的中间件，不含根组
+func (g *RouterGroup) groupMiddlewares() []HandlerFunc {
+	var middlewares []HandlerFunc
+	for group := g; group != nil && group.parent != nil; group = group.parent {
+		middlewares = append(append([]HandlerFunc(nil), group.middlewares...), middlewares...)
+	}
+	return middlewares
+}
+
+// Routes 获取已注册的路由信息
+func (e *Engine) Routes() []RouteInfo {
+	routes := make([]RouteInfo, len(e.routes))
+	copy(routes, e.routes)
+	return routes
 }
 
 // GET 添加 GET 路由
@@ -181,12 +245,17 @@ func (e *Engine) Run(addr string) error {
 // ServeHTTP 实现 http.Handler 接口
 func (e *Engine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c := GetContext(w, r)
+	c.engine = e
 	defer PutContext(c)
 
-	// 添加请求上下文
+	// 添加请求上下文，处理函数的超时不影响 Stream、SSEStream 对客户端断开的判断
+	c.clientCtx = r.Context()
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
+	// 同一请求内的多次校验共享 unique、exists 等规则的查询结果
+	ctx = validator.WithCallCache(ctx)
 	c.Request = c.Request.WithContext(ctx)
+	e.wrapRequestBody(c)
 
 	e.handle(c)
 }
@@ -199,6 +268,7 @@ func (e *Engine) handle(c *Context) {
 
 	if node != nil {
 		c.Params = node.GetParams(c.Request.URL.Path)
+		c.fullPath = node.Pattern
 		// 正确合并全局中间件和路由 handler
 		c.handlers = make([]HandlerFunc, 0, len(middlewares)+len(node.Handlers))
 		c.handlers = append(c.handlers, middlewares...)
@@ -218,21 +288,26 @@ func (e *Engine) handle(c *Context) {
 
 	// 添加错误恢复
 	defer func() {
-		if err := recover(); err != nil {
-			logger.Error("panic recovered",
-				zap.Any("error", err),
-				zap.String("path", c.Request.URL.Path),
-				zap.String("method", c.Request.Method),
-			)
-			c.JSON(http.StatusInternalServerError, map[string]interface{}{
-				"code":    500,
-				"message": "Internal Server Error",
-				"error":   fmt.Sprintf("%v", err),
-			})
+		if v := recover(); v != nil {
+			e.Recovery.recover(c, v)
 		}
 	}()
 
 	c.Next()
+
+	// 统一渲染处理链中记录但未响应的错误
+	if c.HasError() && !c.Writer.Written() {
+		e.handleError(c, c.GetError())
+	}
+}
+
+// handleError 调用错误处理函数
+func (e *Engine) handleError(c *Context, err error) {
+	handler := e.ErrorHandler
+	if handler == nil {
+		handler = DefaultErrorHandler
+	}
+	handler(c, err)
 }
 
 // parsePattern 解析路由模式
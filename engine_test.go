@@ -0,0 +1,46 @@
+package nova
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGroupMiddlewares(t *testing.T) {
+	e := NewEngine()
+	mark := func(name string) HandlerFunc {
+		return func(c *Context) {
+			c.Writer.Header().Add("X-Trace", name)
+			c.Next()
+		}
+	}
+	ok := func(c *Context) { c.String(http.StatusOK, "ok") }
+
+	e.Use(mark("root"))
+	api := e.Group("/api")
+	api.Use(mark("api"))
+	v1 := api.Group("/v1")
+	v1.Use(mark("v1"))
+	v1.GET("/users", mark("route"), ok)
+	api.GET("/ping", ok)
+	e.GET("/home", ok)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v1/users", want: "root,api,v1,route"},
+		{path: "/api/ping", want: "root,api"},
+		{path: "/home", want: "root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if got := strings.Join(w.Header().Values("X-Trace"), ","); got != tt.want {
+				t.Errorf("middlewares = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,13 @@
 package nova
 
+import (
+	"errors"
+	"net/http"
+
+	"github.com/xzl-go/nova/binding"
+	novaerrors "github.com/xzl-go/nova/errors"
+)
+
 // Error 错误类型
 type Error struct {
 	Code    int    `json:"code"`
@@ -17,3 +25,48 @@ func NewError(code int, message string) error {
 func (e *Error) Error() string {
 	return e.Message
 }
+
+// bindingErrorResponse 绑定与校验错误的响应体
+type bindingErrorResponse struct {
+	Code    novaerrors.ErrorCode `json:"code"`
+	Message string               `json:"message"`
+	Fields  []binding.FieldError `json:"fields"`
+}
+
+// ErrorHandlerFunc 错误处理函数类型
+type ErrorHandlerFunc func(c *Context, err error)
+
+// DefaultErrorHandler 默认错误处理函数
+// *errors.Error 按 HTTPStatus 渲染错误码、消息与详情；*binding.Error 渲染为 400 并按 Accept-Language 列出本地化的字段错误；请求体超限渲染为 413；Code 为 HTTP 状态码的 *Error 按原状态码渲染；
+// 其余错误统一渲染为内部错误，避免泄露内部信息
+func DefaultErrorHandler(c *Context, err error) {
+	var appErr *novaerrors.Error
+	if errors.As(err, &appErr) {
+		c.JSON(appErr.HTTPStatus(), appErr)
+		return
+	}
+
+	var bindErr *binding.Error
+	if errors.As(err, &bindErr) {
+		c.JSON(http.StatusBadRequest, bindingErrorResponse{
+			Code:    novaerrors.ErrParamInvalid,
+			Message: novaerrors.GetMessage(novaerrors.ErrParamInvalid),
+			Fields:  bindErr.Localize(c.Language()).Fields,
+		})
+		return
+	}
+
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, novaerrors.New(novaerrors.ErrBodyTooLarge, novaerrors.GetMessage(novaerrors.ErrBodyTooLarge)))
+		return
+	}
+
+	var httpErr *Error
+	if errors.As(err, &httpErr) && httpErr.Code >= 400 && httpErr.Code < 600 {
+		c.JSON(httpErr.Code, httpErr)
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, novaerrors.New(novaerrors.ErrInternal, novaerrors.GetMessage(novaerrors.ErrInternal)))
+}
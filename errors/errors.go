@@ -27,6 +27,7 @@ const (
 	ErrParamInvalid  ErrorCode = 2002 // 参数无效
 	ErrParamType     ErrorCode = 2003 // 参数类型错误
 	ErrParamFormat   ErrorCode = 2004 // 参数格式错误
+	ErrBodyTooLarge  ErrorCode = 2005 // 请求体过大
 
 	// 认证错误 (3000-3999)
 	ErrAuth         ErrorCode = 3000 // 认证错误
@@ -67,6 +68,8 @@ func (e *Error) Unwrap() error {
 // HTTPStatus 返回对应的 HTTP 状态码
 func (e *Error) HTTPStatus() int {
 	switch {
+	case e.Code == ErrBodyTooLarge:
+		return http.StatusRequestEntityTooLarge
 	case e.Code >= 1000 && e.Code < 2000:
 		return http.StatusInternalServerError
 	case e.Code >= 2000 && e.Code < 3000:
@@ -117,6 +120,7 @@ var errorMessages = map[ErrorCode]string{
 	ErrParamInvalid:       "参数无效",
 	ErrParamType:          "参数类型错误",
 	ErrParamFormat:        "参数格式错误",
+	ErrBodyTooLarge:       "请求体过大",
 	ErrAuth:               "认证错误",
 	ErrToken:              "Token错误",
 	ErrTokenExpired:       "Token过期",
@@ -0,0 +1,82 @@
+package nova
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/xzl-go/nova/binding"
+	novaerrors "github.com/xzl-go/nova/errors"
+)
+
+// RouteInfo 路由信息
+type RouteInfo struct {
+	Method   string       // 请求方法
+	Path     string       // 路由模式
+	Request  reflect.Type // 通过 Route 注册的类型化处理函数的请求类型，其余为 nil
+	Response reflect.Type // 通过 Route 注册的类型化处理函数的响应类型，其余为 nil
+}
+
+// TypedHandler 类型化处理函数，携带请求与响应类型，通过 RouterGroup.Route 注册时记录到 Engine.Routes 中
+type TypedHandler struct {
+	Request  reflect.Type // 请求类型
+	Response reflect.Type // 响应类型
+	Handler  HandlerFunc  // 适配后的处理函数
+}
+
+// Handle 将类型化处理函数适配为 HandlerFunc
+// 依次从请求体、查询参数（form 标签）、请求头（header 标签）和路由参数（uri 标签）绑定 Req，后者优先级更高；
+// 绑定后执行 validator 校验，字段错误以 *binding.Error 渲染为 400，并按 Accept 请求头渲染 Resp。返回的错误交由 Engine.ErrorHandler 处理，
+// *errors.Error 按其 HTTPStatus 映射状态码。需要在 Engine.Routes 中记录 Req 与 Resp 类型时使用 NewTypedHandler 与 RouterGroup.Route
+func Handle[Req any, Resp any](fn func(*Context, Req) (Resp, error)) HandlerFunc {
+	return NewTypedHandler(fn).Handler
+}
+
+// NewTypedHandler 创建类型化处理函数，行为与 Handle 相同，并保留 Req 与 Resp 类型用于生成接口文档
+func NewTypedHandler[Req any, Resp any](fn func(*Context, Req) (Resp, error)) TypedHandler {
+	reqType := reflect.TypeOf((*Req)(nil)).Elem()
+	respType := reflect.TypeOf((*Resp)(nil)).Elem()
+
+	handler := func(c *Context) {
+		var req Req
+		target := interface{}(&req)
+		if reqType.Kind() == reflect.Ptr {
+			v := reflect.New(reqType.Elem())
+			req = v.Interface().(Req)
+			target = req
+		}
+
+		if err := c.bindAll(target); err != nil {
+			c.AbortWithError(bindError(err))
+			return
+		}
+		if err := binding.ValidateCtx(c.Request.Context(), target, "json", binding.GroupsFromContext(c.Request.Context())...); err != nil {
+			c.AbortWithError(err)
+			return
+		}
+
+		resp, err := fn(c, req)
+		if err != nil {
+			c.AbortWithError(err)
+			return
+		}
+		if c.Writer.Written() || c.IsAborted() {
+			return
+		}
+		c.Negotiate(http.StatusOK, resp)
+	}
+	return TypedHandler{Request: reqType, Response: respType, Handler: handler}
+}
+
+// bindError 转换绑定错误，请求体超限映射为 413，字段错误保持 *binding.Error 以便渲染字段详情
+func bindError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return novaerrors.Wrap(err, novaerrors.ErrBodyTooLarge, novaerrors.GetMessage(novaerrors.ErrBodyTooLarge))
+	}
+	var bindErr *binding.Error
+	if errors.As(err, &bindErr) {
+		return bindErr
+	}
+	return novaerrors.Wrap(err, novaerrors.ErrParamInvalid, novaerrors.GetMessage(novaerrors.ErrParamInvalid)).WithDetails(err.Error())
+}
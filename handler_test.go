@@ -0,0 +1,143 @@
+package nova
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type updateUserRequest struct {
+	ID      int    `uri:"id" validate:"required"`
+	Notify  bool   `form:"notify"`
+	Tenant  string `header:"X-Tenant" validate:"required"`
+	Name    string `json:"name" validate:"required,min=2"`
+	Ignored string `json:"-"`
+}
+
+type updateUserResponse struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Tenant string `json:"tenant"`
+	Notify bool   `json:"notify"`
+}
+
+// 测试类型化处理函数的绑定、校验与渲染
+func TestHandle(t *testing.T) {
+	e := NewEngine()
+	e.Route("PUT", "/users/:id", NewTypedHandler(func(c *Context, req updateUserRequest) (updateUserResponse, error) {
+		return updateUserResponse{ID: req.ID, Name: req.Name, Tenant: req.Tenant, Notify: req.Notify}, nil
+	}))
+	e.GET("/plain", Handle(func(c *Context, req updateUserRequest) (updateUserResponse, error) {
+		return updateUserResponse{}, nil
+	}))
+
+	tests := []struct {
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{`{"name":"alice"}`, 200, `{"id":42,"name":"alice","tenant":"acme","notify":true}`},
+		{`{"name":"a"}`, 400, `"code":2002`},
+		{`{"name":`, 400, `"code":2002`},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("PUT", "/users/42?notify=true", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("X-Tenant", "acme")
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		if w.Code != tt.wantCode || !strings.Contains(w.Body.String(), tt.wantBody) {
+			t.Fatalf("body %s: got %d %s, want %d containing %s", tt.body, w.Code, w.Body.String(), tt.wantCode, tt.wantBody)
+		}
+	}
+
+	routes := e.Routes()
+	if len(routes) != 2 || routes[0].Request != reflect.TypeOf(updateUserRequest{}) || routes[0].Response != reflect.TypeOf(updateUserResponse{}) {
+		t.Fatalf("typed route not recorded: %+v", routes)
+	}
+	if routes[1].Request != nil || routes[1].Response != nil {
+		t.Fatalf("plain route should not carry types: %+v", routes[1])
+	}
+}
+
+type signupRequest struct {
+	Email string `json:"email" label:"Email address" validate:"required"`
+	Age   int    `json:"age" validate:"min=18"`
+}
+
+// 测试校验错误按 Accept-Language 本地化并使用 label 标签
+func TestHandleLocalizedErrors(t *testing.T) {
+	e := NewEngine()
+	e.POST("/signup", Handle(func(c *Context, req signupRequest) (signupRequest, error) {
+		return req, nil
+	}))
+
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{"", []string{"Email address 不能为空", "age 不能小于 18"}},
+		{"en-GB,en;q=0.9", []string{"Email address is required", "age must be at least 18"}},
+		{"fr, ja;q=0.5", []string{"Email address は必須です", "age は 18 以上である必要があります"}},
+		{"ko-KR", []string{"Email address 은(는) 필수입니다"}},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/signup", strings.NewReader(`{"age":3}`))
+		r.Header.Set("Content-Type", "application/json")
+		if tt.lang != "" {
+			r.Header.Set("Accept-Language", tt.lang)
+		}
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		for _, want := range tt.want {
+			if w.Code != 400 || !strings.Contains(w.Body.String(), want) {
+				t.Fatalf("lang %q: got %d %s, want %q", tt.lang, w.Code, w.Body.String(), want)
+			}
+		}
+	}
+}
+
+type listRequest struct {
+	Page int    `json:"page" form:"page" default:"1"`
+	Size int    `json:"size" form:"size" default:"10"`
+	Sort string `json:"sort" form:"sort" default:"id"`
+}
+
+// 测试 default 标签只作用于所有来源都未提供的字段
+func TestHandleDefaults(t *testing.T) {
+	e := NewEngine()
+	e.POST("/items", Handle(func(c *Context, req listRequest) (listRequest, error) {
+		return req, nil
+	}))
+
+	tests := []struct {
+		query string
+		body  string
+		want  string
+	}{
+		{"", `{"page":5,"size":50}`, `{"page":5,"size":50,"sort":"id"}`},
+		{"?size=20", `{"page":5}`, `{"page":5,"size":20,"sort":"id"}`},
+		{"?sort=name", `{}`, `{"page":1,"size":10,"sort":"name"}`},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/items"+tt.query, strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		if strings.TrimSpace(w.Body.String()) != tt.want {
+			t.Errorf("query %q body %s: got %s, want %s", tt.query, tt.body, w.Body.String(), tt.want)
+		}
+
+		var req listRequest
+		c := NewContext(httptest.NewRecorder(), httptest.NewRequest("POST", "/items"+tt.query, strings.NewReader(tt.body)))
+		c.Request.Header.Set("Content-Type", "application/json")
+		if err := c.ShouldBindAll(&req); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := json.Marshal(req); string(got) != tt.want {
+			t.Errorf("ShouldBindAll query %q body %s: got %s, want %s", tt.query, tt.body, got, tt.want)
+		}
+	}
+}
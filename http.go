@@ -2,6 +2,7 @@ package nova
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -20,6 +21,7 @@ import (
 type HTTPClient struct {
 	client  *http.Client
 	headers map[string]string
+	ctx     context.Context
 }
 
 // NewHTTPClient 创建 HTTP 客户端
@@ -49,9 +51,22 @@ func (c *HTTPClient) SetHeaders(headers map[string]string) {
 	}
 }
 
-// Get 发送 GET 请求
-func (c *HTTPClient) Get(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// WithContext 返回使用 ctx 发送请求的客户端副本，ctx 中的请求 ID 通过 X-Request-ID 请求头传递给下游服务
+func (c *HTTPClient) WithContext(ctx context.Context) *HTTPClient {
+	cp := &HTTPClient{client: c.client, headers: make(map[string]string, len(c.headers)), ctx: ctx}
+	for k, v := range c.headers {
+		cp.headers[k] = v
+	}
+	return cp
+}
+
+// newRequest 创建请求并设置公共请求头与请求 ID
+func (c *HTTPClient) newRequest(method, url string, body io.Reader) (*http.Request, error) {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +74,18 @@ func (c *HTTPClient) Get(url string) ([]byte, error) {
 	for k, v := range c.headers {
 		req.Header.Set(k, v)
 	}
+	if id := RequestIDFromContext(ctx); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	return req, nil
+}
+
+// Get 发送 GET 请求
+func (c *HTTPClient) Get(url string) ([]byte, error) {
+	req, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -80,14 +107,11 @@ func (c *HTTPClient) Post(url string, data interface{}) ([]byte, error) {
 		body = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequest("POST", url, body)
+	req, err := c.newRequest("POST", url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
 	if data != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -112,14 +136,11 @@ func (c *HTTPClient) Put(url string, data interface{}) ([]byte, error) {
 		body = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequest("PUT", url, body)
+	req, err := c.newRequest("PUT", url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
 	if data != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -135,15 +156,11 @@ func (c *HTTPClient) Put(url string, data interface{}) ([]byte, error) {
 
 // Delete 发送 DELETE 请求
 func (c *HTTPClient) Delete(url string) ([]byte, error) {
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := c.newRequest("DELETE", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
-
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -155,14 +172,11 @@ func (c *HTTPClient) Delete(url string) ([]byte, error) {
 
 // PostForm 发送表单 POST 请求
 func (c *HTTPClient) PostForm(url string, data url.Values) ([]byte, error) {
-	req, err := http.NewRequest("POST", url, strings.NewReader(data.Encode()))
+	req, err := c.newRequest("POST", url, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := c.client.Do(req)
@@ -207,14 +221,11 @@ func (c *HTTPClient) UploadFile(url string, fieldName, filePath string, extraFie
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", url, body)
+	req, err := c.newRequest("POST", url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
 	resp, err := c.client.Do(req)
@@ -228,7 +239,12 @@ func (c *HTTPClient) UploadFile(url string, fieldName, filePath string, extraFie
 
 // DownloadFile 下载文件
 func (c *HTTPClient) DownloadFile(url string, filePath string) error {
-	resp, err := c.client.Get(url)
+	req, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -42,6 +44,16 @@ func (i *I18n) LoadMessages(lang Language, messages map[string]string) {
 	i.messages[lang] = messages
 }
 
+// SetMessage 设置单条消息，语言包不存在时自动创建
+func (i *I18n) SetMessage(lang Language, key, message string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.messages[lang] == nil {
+		i.messages[lang] = make(map[string]string)
+	}
+	i.messages[lang][key] = message
+}
+
 // LoadFromFile 从文件加载语言包
 func (i *I18n) LoadFromFile(lang Language, filename string) error {
 	data, err := os.ReadFile(filename)
@@ -89,22 +101,15 @@ func (i *I18n) Translate(lang Language, key string, args ...interface{}) string
 	i.mu.RLock()
 	defer i.mu.RUnlock()
 
-	// 获取语言包
-	messages, ok := i.messages[lang]
+	// 获取消息，指定语言缺少该消息时使用默认语言
+	message, ok := i.messages[lang][key]
 	if !ok {
-		// 如果找不到指定语言，使用默认语言
-		messages, ok = i.messages[i.defaultLang]
+		message, ok = i.messages[i.defaultLang][key]
 		if !ok {
 			return key
 		}
 	}
 
-	// 获取消息
-	message, ok := messages[key]
-	if !ok {
-		return key
-	}
-
 	// 格式化消息
 	if len(args) > 0 {
 		return fmt.Sprintf(message, args...)
@@ -145,3 +150,70 @@ func (i *I18n) HasLanguage(lang Language) bool {
 	_, ok := i.messages[lang]
 	return ok
 }
+
+// MatchLanguage 按 Accept-Language 请求头从已加载的语言中选择最合适的语言
+// 先按 q 值精确匹配，再按主语言匹配（如 en、en-GB 匹配 en-US），无匹配时返回默认语言
+func (i *I18n) MatchLanguage(acceptLanguage string) Language {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	tags := make([]weighted, 0, 4)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(a, b int) bool { return tags[a].q > tags[b].q })
+
+	loaded := make([]Language, 0, len(i.messages))
+	for lang := range i.messages {
+		loaded = append(loaded, lang)
+	}
+	sort.Slice(loaded, func(a, b int) bool { return loaded[a] < loaded[b] })
+
+	for _, t := range tags {
+		if t.tag == "*" {
+			return i.defaultLang
+		}
+		for _, lang := range loaded {
+			if strings.EqualFold(string(lang), t.tag) {
+				return lang
+			}
+		}
+		base := primaryTag(t.tag)
+		if strings.EqualFold(primaryTag(string(i.defaultLang)), base) {
+			return i.defaultLang
+		}
+		for _, lang := range loaded {
+			if strings.EqualFold(primaryTag(string(lang)), base) {
+				return lang
+			}
+		}
+	}
+	return i.defaultLang
+}
+
+// primaryTag 获取语言标签的主语言部分，如 en-US 返回 en
+func primaryTag(tag string) string {
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
@@ -0,0 +1,28 @@
+package nova
+
+import "github.com/xzl-go/nova/binding"
+
+// StrictJSON 设置当前路由的 JSON 绑定选项，覆盖 Engine.JSONOptions
+func StrictJSON(opts binding.JSONOptions) HandlerFunc {
+	return func(c *Context) {
+		c.SetJSONOptions(&opts)
+		c.Next()
+	}
+}
+
+// SetJSONOptions 设置当前请求的 JSON 绑定选项，nil 表示沿用 Engine.JSONOptions
+func (c *Context) SetJSONOptions(opts *binding.JSONOptions) {
+	c.jsonOptions = opts
+}
+
+// jsonBinding 获取当前请求生效的 JSON 绑定器
+func (c *Context) jsonBinding() binding.JSONBinding {
+	switch {
+	case c.jsonOptions != nil:
+		return binding.JSONBinding{Options: c.jsonOptions}
+	case c.engine != nil:
+		return binding.JSONBinding{Options: c.engine.JSONOptions}
+	default:
+		return binding.JSON
+	}
+}
@@ -0,0 +1,215 @@
+package nova
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidCookie Cookie 签名校验或解密失败
+	ErrInvalidCookie = errors.New("nova: invalid cookie")
+	// ErrNoKeyring 未配置密钥环
+	ErrNoKeyring = errors.New("nova: cookie keyring not configured")
+	// ErrCookieExpired Cookie 签名或解密成功但已超过有效期
+	ErrCookieExpired = errors.New("nova: cookie expired")
+)
+
+// DefaultSessionMaxAge 未指定有效期的签名与加密值默认的有效期
+const DefaultSessionMaxAge = 24 * time.Hour
+
+// expiresSize 值中过期时间的字节数
+const expiresSize = 8
+
+// Keyring 可轮换的密钥环，用于签名与加密 Cookie
+// 第一个密钥为当前密钥，用于签名和加密；其余为旧密钥，仅用于校验和解密，
+// 以便轮换密钥时已签发的 Cookie 仍然有效。签名与加密的值包含过期时间，过期后校验失败
+type Keyring struct {
+	// SessionMaxAge maxAge 小于等于 0 时使用的有效期，用于会话 Cookie，0 表示不过期
+	SessionMaxAge time.Duration
+
+	mu   sync.RWMutex
+	keys []derivedKey
+	now  func() time.Time
+}
+
+// derivedKey 由原始密钥派生出的签名密钥与加密算法
+type derivedKey struct {
+	sign []byte
+	aead cipher.AEAD
+}
+
+// NewKeyring 创建密钥环，keys[0] 为当前密钥
+func NewKeyring(keys ...[]byte) *Keyring {
+	k := &Keyring{SessionMaxAge: DefaultSessionMaxAge}
+	for _, key := range keys {
+		k.keys = append(k.keys, deriveKey(key))
+	}
+	return k
+}
+
+// Rotate 轮换密钥，新密钥成为当前密钥，最多保留 keep 个旧密钥
+func (k *Keyring) Rotate(key []byte, keep int) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	keys := append([]derivedKey{deriveKey(key)}, k.keys...)
+	if keep >= 0 && len(keys) > keep+1 {
+		keys = keys[:keep+1]
+	}
+	k.keys = keys
+}
+
+// Len 获取密钥数量
+func (k *Keyring) Len() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return len(k.keys)
+}
+
+// Sign 使用当前密钥对值签名，name 参与签名以防止不同 Cookie 间互换
+// 过期时间与值一同签名，maxAge 小于等于 0 时使用 SessionMaxAge
+func (k *Keyring) Sign(name, value string, maxAge time.Duration) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.keys) == 0 {
+		return "", ErrNoKeyring
+	}
+	payload := k.payload(value, maxAge)
+	mac := computeMAC(k.keys[0].sign, name, string(payload))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac), nil
+}
+
+// Verify 使用所有密钥依次校验签名，返回原始值；签名有效但已过期时返回 ErrCookieExpired
+func (k *Keyring) Verify(name, signed string) (string, error) {
+	encValue, encMAC, ok := strings.Cut(signed, ".")
+	if !ok {
+		return "", ErrInvalidCookie
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encValue)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encMAC)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.keys) == 0 {
+		return "", ErrNoKeyring
+	}
+	for _, key := range k.keys {
+		if hmac.Equal(mac, computeMAC(key.sign, name, string(payload))) {
+			return k.open(payload)
+		}
+	}
+	return "", ErrInvalidCookie
+}
+
+// Encrypt 使用当前密钥加密值（AES-256-GCM），name 作为附加认证数据
+// 过期时间与值一同加密，maxAge 小于等于 0 时使用 SessionMaxAge
+func (k *Keyring) Encrypt(name, value string, maxAge time.Duration) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.keys) == 0 {
+		return "", ErrNoKeyring
+	}
+	aead := k.keys[0].aead
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, k.payload(value, maxAge), []byte(name))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 使用所有密钥依次尝试解密，解密成功但已过期时返回 ErrCookieExpired
+func (k *Keyring) Decrypt(name, encrypted string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.keys) == 0 {
+		return "", ErrNoKeyring
+	}
+	for _, key := range k.keys {
+		nonceSize := key.aead.NonceSize()
+		if len(data) < nonceSize {
+			return "", ErrInvalidCookie
+		}
+		plaintext, err := key.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(name))
+		if err == nil {
+			return k.open(plaintext)
+		}
+	}
+	return "", ErrInvalidCookie
+}
+
+// payload 生成带过期时间的值，前 8 字节为过期时间的 Unix 秒数，0 表示不过期
+func (k *Keyring) payload(value string, maxAge time.Duration) []byte {
+	if maxAge <= 0 {
+		maxAge = k.SessionMaxAge
+	}
+	var expires int64
+	if maxAge > 0 {
+		expires = k.clock().Add(maxAge).Unix()
+	}
+	payload := make([]byte, expiresSize, expiresSize+len(value))
+	binary.BigEndian.PutUint64(payload, uint64(expires))
+	return append(payload, value...)
+}
+
+// open 校验 payload 的过期时间并返回原始值
+func (k *Keyring) open(payload []byte) (string, error) {
+	if len(payload) < expiresSize {
+		return "", ErrInvalidCookie
+	}
+	expires := int64(binary.BigEndian.Uint64(payload))
+	if expires != 0 && k.clock().Unix() >= expires {
+		return "", ErrCookieExpired
+	}
+	return string(payload[expiresSize:]), nil
+}
+
+// clock 获取当前时间
+func (k *Keyring) clock() time.Time {
+	if k.now != nil {
+		return k.now()
+	}
+	return time.Now()
+}
+
+// deriveKey 从原始密钥派生相互独立的签名密钥与加密密钥
+func deriveKey(key []byte) derivedKey {
+	signKey := hmac.New(sha256.New, key)
+	signKey.Write([]byte("nova-cookie-sign"))
+	encKey := hmac.New(sha256.New, key)
+	encKey.Write([]byte("nova-cookie-encrypt"))
+
+	// 32 字节密钥必定可以创建 AES-256 与 GCM，错误可以忽略
+	block, _ := aes.NewCipher(encKey.Sum(nil))
+	aead, _ := cipher.NewGCM(block)
+	return derivedKey{sign: signKey.Sum(nil), aead: aead}
+}
+
+// computeMAC 计算 Cookie 签名
+func computeMAC(key []byte, name, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(name))
+	h.Write([]byte{'='})
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// fieldsKey context 中保存日志字段的键
+type fieldsKey struct{}
+
+// NewContext 返回附加了日志字段的 context，通过 Ctx 获取的日志对象会带上这些字段
+func NewContext(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	merged := append(append([]zap.Field(nil), ContextFields(ctx)...), fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// ContextFields 获取 context 中附加的日志字段
+func ContextFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	return fields
+}
+
+// Ctx 获取带有 context 日志字段（如 request_id）的日志对象，全局日志未初始化时返回不输出的日志对象
+func Ctx(ctx context.Context) *zap.Logger {
+	if Log == nil {
+		return zap.NewNop()
+	}
+	fields := ContextFields(ctx)
+	if len(fields) == 0 {
+		return Log
+	}
+	return Log.With(fields...)
+}
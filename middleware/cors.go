@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xzl-go/nova"
+)
+
+// CORSConfig 跨域配置
+type CORSConfig struct {
+	// AllowOrigins 允许的来源，如 https://example.com；* 表示任意来源，https://*.example.com 匹配任意子域名
+	AllowOrigins []string
+	// AllowOriginFunc 自定义来源判断，与 AllowOrigins 任一匹配即允许
+	AllowOriginFunc func(origin string) bool
+	// AllowMethods 预检请求允许的方法，默认为 GET、POST、PUT、PATCH、DELETE、HEAD
+	AllowMethods []string
+	// AllowHeaders 预检请求允许的请求头，为空时使用默认列表，* 表示回显预检请求声明的请求头
+	AllowHeaders []string
+	// ExposeHeaders 允许浏览器读取的响应头
+	ExposeHeaders []string
+	// AllowCredentials 是否允许携带 Cookie 等凭证，启用时 Access-Control-Allow-Origin 回显请求来源；
+	// 不能与 AllowOrigins 中的 * 同时使用，需列出来源或使用 AllowOriginFunc
+	AllowCredentials bool
+	// MaxAge 预检结果缓存时间，0 表示不设置
+	MaxAge time.Duration
+	// AllowPrivateNetwork 是否允许公网页面访问私有网络，响应 Access-Control-Request-Private-Network 预检
+	AllowPrivateNetwork bool
+}
+
+// DefaultCORSConfig 默认跨域配置，允许任意来源且不携带凭证
+var DefaultCORSConfig = CORSConfig{
+	AllowOrigins: []string{"*"},
+	MaxAge:       24 * time.Hour,
+}
+
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead}
+	defaultCORSHeaders = []string{"Origin", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization"}
+)
+
+// CORS 跨域中间件，使用 DefaultCORSConfig
+func CORS() nova.HandlerFunc {
+	return CORSWithConfig(DefaultCORSConfig)
+}
+
+// CORSWithConfig 按配置创建跨域中间件，可通过 RouterGroup.Use 为不同路由组使用不同配置
+// 仅 OPTIONS 且携带 Access-Control-Request-Method 的请求视为预检请求，直接返回 204；其余 OPTIONS 请求交给路由处理
+// AllowCredentials 与任意来源 * 同时配置时 panic，避免任意站点发起携带凭证的跨域请求
+func CORSWithConfig(config CORSConfig) nova.HandlerFunc {
+	if len(config.AllowMethods) == 0 {
+		config.AllowMethods = defaultCORSMethods
+	}
+	if len(config.AllowHeaders) == 0 {
+		config.AllowHeaders = defaultCORSHeaders
+	}
+	matcher := newOriginMatcher(config.AllowOrigins, config.AllowOriginFunc)
+	if matcher.any && config.AllowCredentials {
+		panic(`middleware: CORSConfig cannot combine AllowOrigins "*" with AllowCredentials; list the origins or use AllowOriginFunc`)
+	}
+	allowMethods := strings.Join(config.AllowMethods, ", ")
+	allowHeaders := strings.Join(config.AllowHeaders, ", ")
+	reflectHeaders := allowHeaders == "*"
+	exposeHeaders := strings.Join(config.ExposeHeaders, ", ")
+	maxAge := ""
+	if config.MaxAge > 0 {
+		maxAge = strconv.FormatInt(int64(config.MaxAge/time.Second), 10)
+	}
+
+	return func(c *nova.Context) {
+		header := c.Writer.Header()
+		origin := c.Request.Header.Get("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != ""
+
+		header.Add("Vary", "Origin")
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		allowed := matcher.match(origin)
+		if preflight && !allowed {
+			c.Writer.WriteHeader(http.StatusForbidden)
+			c.Abort()
+			return
+		}
+		if !allowed {
+			c.Next()
+			return
+		}
+
+		if matcher.any {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if config.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposeHeaders != "" {
+				c.Header("Access-Control-Expose-Headers", exposeHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Methods", allowMethods)
+		if reflectHeaders {
+			if requested := c.Request.Header.Get("Access-Control-Request-Headers"); requested != "" {
+				c.Header("Access-Control-Allow-Headers", requested)
+			}
+		} else {
+			c.Header("Access-Control-Allow-Headers", allowHeaders)
+		}
+		if maxAge != "" {
+			c.Header("Access-Control-Max-Age", maxAge)
+		}
+		if config.AllowPrivateNetwork && c.Request.Header.Get("Access-Control-Request-Private-Network") == "true" {
+			c.Header("Access-Control-Allow-Private-Network", "true")
+		}
+		c.Writer.WriteHeader(http.StatusNoContent)
+		c.Abort()
+	}
+}
+
+// originMatcher 来源匹配器
+type originMatcher struct {
+	any       bool
+	exact     map[string]bool
+	wildcards [][2]string // 通配符前后缀，如 https:// 与 .example.com
+	fn        func(origin string) bool
+}
+
+// newOriginMatcher 创建来源匹配器，来源不区分大小写
+func newOriginMatcher(origins []string, fn func(origin string) bool) *originMatcher {
+	m := &originMatcher{exact: make(map[string]bool), fn: fn}
+	for _, origin := range origins {
+		origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+		switch {
+		case origin == "*":
+			m.any = true
+		case strings.Contains(origin, "*"):
+			i := strings.IndexByte(origin, '*')
+			m.wildcards = append(m.wildcards, [2]string{origin[:i], origin[i+1:]})
+		default:
+			m.exact[origin] = true
+		}
+	}
+	return m
+}
+
+// match 检查来源是否允许
+func (m *originMatcher) match(origin string) bool {
+	if m.any {
+		return true
+	}
+	lower := strings.ToLower(origin)
+	if m.exact[lower] {
+		return true
+	}
+	for _, w := range m.wildcards {
+		if len(lower) > len(w[0])+len(w[1]) && strings.HasPrefix(lower, w[0]) && strings.HasSuffix(lower, w[1]) {
+			return true
+		}
+	}
+	return m.fn != nil && m.fn(origin)
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xzl-go/nova"
+)
+
+func TestCORSWithConfig(t *testing.T) {
+	e := nova.NewEngine()
+	api := e.Group("/api")
+	api.Use(CORSWithConfig(CORSConfig{
+		AllowOrigins:        []string{"https://app.example.com", "https://*.example.org"},
+		AllowOriginFunc:     func(origin string) bool { return origin == "http://localhost:3000" },
+		ExposeHeaders:       []string{"X-Total"},
+		AllowCredentials:    true,
+		MaxAge:              10 * time.Minute,
+		AllowPrivateNetwork: true,
+	}))
+	api.GET("/items", func(c *nova.Context) {
+		c.String(http.StatusOK, "%s", c.Request.Method)
+	})
+	e.GET("/public", CORS(), func(c *nova.Context) {
+		c.String(http.StatusOK, "%s", c.Request.Method)
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		headers map[string]string
+		status  int
+		body    string
+		want    map[string]string
+	}{
+		{
+			name:    "simple request",
+			method:  http.MethodGet,
+			path:    "/api/items",
+			headers: map[string]string{"Origin": "https://app.example.com"},
+			status:  http.StatusOK,
+			body:    "GET",
+			want: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Total",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			name:    "wildcard subdomain",
+			method:  http.MethodGet,
+			path:    "/api/items",
+			headers: map[string]string{"Origin": "https://a.b.example.org"},
+			status:  http.StatusOK,
+			want:    map[string]string{"Access-Control-Allow-Origin": "https://a.b.example.org"},
+		},
+		{
+			name:    "predicate",
+			method:  http.MethodGet,
+			path:    "/api/items",
+			headers: map[string]string{"Origin": "http://localhost:3000"},
+			status:  http.StatusOK,
+			want:    map[string]string{"Access-Control-Allow-Origin": "http://localhost:3000"},
+		},
+		{
+			name:    "disallowed origin",
+			method:  http.MethodGet,
+			path:    "/api/items",
+			headers: map[string]string{"Origin": "https://example.org.evil.com"},
+			status:  http.StatusOK,
+			want:    map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "preflight",
+			method: http.MethodOptions,
+			path:   "/api/items",
+			headers: map[string]string{
+				"Origin":                                 "https://app.example.com",
+				"Access-Control-Request-Method":          "PUT",
+				"Access-Control-Request-Private-Network": "true",
+			},
+			status: http.StatusNoContent,
+			want: map[string]string{
+				"Access-Control-Allow-Origin":          "https://app.example.com",
+				"Access-Control-Allow-Methods":         "GET, POST, PUT, PATCH, DELETE, HEAD",
+				"Access-Control-Max-Age":               "600",
+				"Access-Control-Allow-Private-Network": "true",
+				"Access-Control-Expose-Headers":        "",
+			},
+		},
+		{
+			name:    "disallowed preflight",
+			method:  http.MethodOptions,
+			path:    "/api/items",
+			headers: map[string]string{"Origin": "https://evil.com", "Access-Control-Request-Method": "PUT"},
+			status:  http.StatusForbidden,
+			want:    map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:    "options without preflight",
+			method:  http.MethodOptions,
+			path:    "/api/items",
+			headers: map[string]string{"Origin": "https://app.example.com"},
+			status:  http.StatusOK,
+			body:    "OPTIONS",
+		},
+		{
+			name:    "default any origin",
+			method:  http.MethodGet,
+			path:    "/public",
+			headers: map[string]string{"Origin": "https://any.com"},
+			status:  http.StatusOK,
+			want:    map[string]string{"Access-Control-Allow-Origin": "*", "Access-Control-Allow-Credentials": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.body)
+			}
+			for k, v := range tt.want {
+				if got := w.Header().Get(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestCORSCredentialsWithAnyOrigin(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal(`AllowOrigins "*" with AllowCredentials should panic`)
+		}
+	}()
+	CORSWithConfig(CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true})
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/xzl-go/nova"
+	"github.com/xzl-go/nova/auth"
+	novaerrors "github.com/xzl-go/nova/errors"
+)
+
+const (
+	// ClaimsKey Context 中保存 *auth.Claims 的键
+	ClaimsKey = "claims"
+	// UserIDKey Context 中保存用户 ID 的键，访问日志默认读取该键
+	UserIDKey = "user_id"
+)
+
+// JWTConfig JWT 认证配置
+type JWTConfig struct {
+	// Secret HS256 签名密钥，与 KeyFunc 至少设置一个
+	Secret []byte
+	// KeyFunc 自定义密钥函数，用于非对称算法或密钥轮换，设置时优先于 Secret
+	KeyFunc jwt.Keyfunc
+	// TokenLookup 令牌来源，按顺序尝试，形如 header:Authorization,cookie:token,query:access_token；默认为 header:Authorization
+	// 请求头来源要求 Bearer 前缀
+	TokenLookup string
+	// Issuer 要求的签发者，为空表示不校验
+	Issuer string
+	// Audience 要求的受众，为空表示不校验
+	Audience string
+	// Leeway 校验过期与生效时间时允许的时钟偏差
+	Leeway time.Duration
+	// Realm WWW-Authenticate 中的 realm，为空时不输出
+	Realm string
+	// Skip 返回 true 时跳过认证
+	Skip func(c *nova.Context) bool
+}
+
+// tokenSource 令牌来源
+type tokenSource struct {
+	kind string // header、cookie 或 query
+	name string
+}
+
+// Auth 认证中间件，使用 secret 校验 Authorization 请求头中的 HS256 Bearer 令牌
+func Auth(secret []byte) nova.HandlerFunc {
+	return JWT(JWTConfig{Secret: secret})
+}
+
+// JWT 创建 JWT 认证中间件，认证通过后将 *auth.Claims 与用户 ID 保存到 Context，可通过 GetClaims、GetUserID 读取
+// 认证失败时按 RFC 6750 设置 WWW-Authenticate 响应头，并通过 Engine.ErrorHandler 渲染 401
+// Secret 与 KeyFunc 均未设置时 panic，避免使用默认密钥接受伪造的令牌
+func JWT(config JWTConfig) nova.HandlerFunc {
+	if len(config.Secret) == 0 && config.KeyFunc == nil {
+		panic("middleware: JWTConfig requires Secret or KeyFunc")
+	}
+	if config.TokenLookup == "" {
+		config.TokenLookup = "header:Authorization"
+	}
+	sources := parseTokenLookup(config.TokenLookup)
+	opts := auth.ParseOptions{
+		Secret:   config.Secret,
+		KeyFunc:  config.KeyFunc,
+		Issuer:   config.Issuer,
+		Audience: config.Audience,
+		Leeway:   config.Leeway,
+	}
+
+	return func(c *nova.Context) {
+		if config.Skip != nil && config.Skip(c) {
+			c.Next()
+			return
+		}
+
+		token := extractToken(c, sources)
+		if token == "" {
+			// 请求未携带认证信息时不返回错误码
+			c.Header("WWW-Authenticate", bearerChallenge(config.Realm, "", ""))
+			c.AbortWithError(novaerrors.New(novaerrors.ErrAuth, novaerrors.GetMessage(novaerrors.ErrAuth)))
+			return
+		}
+
+		claims, err := auth.ParseTokenWithOptions(token, opts)
+		if err != nil {
+			code, description := novaerrors.ErrToken, "the access token is invalid"
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				code, description = novaerrors.ErrTokenExpired, "the access token expired"
+			}
+			c.Header("WWW-Authenticate", bearerChallenge(config.Realm, "invalid_token", description))
+			c.AbortWithError(novaerrors.Wrap(err, code, novaerrors.GetMessage(code)))
+			return
+		}
+
+		c.Set(ClaimsKey, claims)
+		c.Set(UserIDKey, claims.UserID)
+		c.Next()
+	}
+}
+
+// GetClaims 获取 JWT 中间件保存的令牌声明
+func GetClaims(c *nova.Context) (*auth.Claims, bool) {
+	v, ok := c.Get(ClaimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(*auth.Claims)
+	return claims, ok
+}
+
+// GetUserID 获取当前认证用户的 ID
+func GetUserID(c *nova.Context) (uint, bool) {
+	claims, ok := GetClaims(c)
+	if !ok {
+		return 0, false
+	}
+	return claims.UserID, true
+}
+
+// GetUsername 获取当前认证用户的用户名
+func GetUsername(c *nova.Context) (string, bool) {
+	claims, ok := GetClaims(c)
+	if !ok {
+		return "", false
+	}
+	return claims.Username, true
+}
+
+// parseTokenLookup 解析令牌来源配置，忽略格式错误的项
+func parseTokenLookup(lookup string) []tokenSource {
+	var sources []tokenSource
+	for _, item := range strings.Split(lookup, ",") {
+		kind, name, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok || name == "" {
+			continue
+		}
+		sources = append(sources, tokenSource{kind: kind, name: name})
+	}
+	return sources
+}
+
+// extractToken 按来源顺序提取令牌
+func extractToken(c *nova.Context, sources []tokenSource) string {
+	for _, src := range sources {
+		var token string
+		switch src.kind {
+		case "header":
+			scheme, value, ok := strings.Cut(c.Request.Header.Get(src.name), " ")
+			if ok && strings.EqualFold(scheme, "Bearer") {
+				token = strings.TrimSpace(value)
+			}
+		case "cookie":
+			if cookie, err := c.Request.Cookie(src.name); err == nil {
+				token = cookie.Value
+			}
+		case "query":
+			token = c.Request.URL.Query().Get(src.name)
+		}
+		if token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// bearerChallenge 生成 RFC 6750 的 WWW-Authenticate 响应头
+func bearerChallenge(realm, code, description string) string {
+	var params []string
+	if realm != "" {
+		params = append(params, fmt.Sprintf("realm=%q", realm))
+	}
+	if code != "" {
+		params = append(params, fmt.Sprintf("error=%q", code))
+	}
+	if description != "" {
+		params = append(params, fmt.Sprintf("error_description=%q", description))
+	}
+	if len(params) == 0 {
+		return "Bearer"
+	}
+	return "Bearer " + strings.Join(params, ", ")
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/xzl-go/nova"
+	"github.com/xzl-go/nova/auth"
+)
+
+func TestJWT(t *testing.T) {
+	secret := []byte("test-secret")
+	sign := func(issuer string, expires time.Duration) string {
+		claims := auth.Claims{
+			UserID:   42,
+			Username: "tom",
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Audience:  jwt.ClaimStrings{"api"},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(expires)),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	e := nova.NewEngine()
+	e.GET("/me", JWT(JWTConfig{
+		Secret:      secret,
+		TokenLookup: "header:Authorization,cookie:token,query:access_token",
+		Issuer:      "nova",
+		Audience:    "api",
+		Leeway:      5 * time.Second,
+		Realm:       "example",
+	}), func(c *nova.Context) {
+		id, _ := GetUserID(c)
+		name, _ := GetUsername(c)
+		c.String(http.StatusOK, "%d %s", id, name)
+	})
+
+	valid := sign("nova", time.Hour)
+	tests := []struct {
+		name      string
+		setup     func(r *http.Request)
+		status    int
+		body      string
+		challenge string
+	}{
+		{
+			name:   "header",
+			setup:  func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+valid) },
+			status: http.StatusOK,
+			body:   "42 tom",
+		},
+		{
+			name:   "cookie",
+			setup:  func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "token", Value: valid}) },
+			status: http.StatusOK,
+			body:   "42 tom",
+		},
+		{
+			name:   "query",
+			setup:  func(r *http.Request) { r.URL.RawQuery = "access_token=" + valid },
+			status: http.StatusOK,
+			body:   "42 tom",
+		},
+		{
+			name:      "missing",
+			setup:     func(r *http.Request) { r.Header.Set("Authorization", "Basic dXNlcjpwYXNz") },
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer realm="example"`,
+		},
+		{
+			name:      "expired",
+			setup:     func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+sign("nova", -time.Minute)) },
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer realm="example", error="invalid_token", error_description="the access token expired"`,
+		},
+		{
+			name:   "within leeway",
+			setup:  func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+sign("nova", -2*time.Second)) },
+			status: http.StatusOK,
+			body:   "42 tom",
+		},
+		{
+			name:      "wrong issuer",
+			setup:     func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+sign("other", time.Hour)) },
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer realm="example", error="invalid_token", error_description="the access token is invalid"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			tt.setup(req)
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.status, w.Body.String())
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.body)
+			}
+			if got := w.Header().Get("WWW-Authenticate"); got != tt.challenge {
+				t.Errorf("WWW-Authenticate = %q, want %q", got, tt.challenge)
+			}
+		})
+	}
+}
+
+func TestJWTRequiresKey(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("JWT without Secret or KeyFunc should panic")
+		}
+	}()
+	JWT(JWTConfig{})
+}
+
+func TestAuthRejectsForeignSecret(t *testing.T) {
+	// 使用配置文件默认密钥签发的令牌不能通过其他密钥的校验
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{UserID: 1}).SignedString([]byte("your-secret-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := nova.NewEngine()
+	e.GET("/me", Auth([]byte("server-secret")), func(c *nova.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+forged)
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
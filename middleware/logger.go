@@ -0,0 +1,346 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/xzl-go/nova"
+	"github.com/xzl-go/nova/logger"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// DefaultMaxLogBodyBytes 请求体与响应体日志默认截断长度
+	DefaultMaxLogBodyBytes = 4 << 10
+	// redacted 脱敏后的占位值
+	redacted = "[REDACTED]"
+)
+
+// defaultRedactHeaders 始终脱敏的请求头与响应头
+var defaultRedactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// LoggerConfig 访问日志配置
+type LoggerConfig struct {
+	// Logger 输出日志的 zap 实例，nil 时使用 logger.Log，二者均为 nil 时不输出
+	Logger *zap.Logger
+	// SkipPaths 不记录日志的请求路径，同时匹配实际路径与路由模式
+	SkipPaths []string
+	// Skip 返回 true 时不记录日志，在处理链执行完毕后调用
+	Skip func(c *nova.Context) bool
+	// SampleRate 状态码小于 400 的请求的采样比例，取值 (0,1) 时生效，0 表示全部记录；4xx 与 5xx 始终记录
+	SampleRate float64
+	// UserIDKey 从 Context 中读取用户 ID 的键，默认为 user_id
+	UserIDKey string
+	// RequestIDHeader 未使用 RequestID 中间件时读取请求 ID 的请求头，默认为 X-Request-ID
+	RequestIDHeader string
+
+	// LogHeaders 是否记录请求头，Authorization 与 Cookie 等始终脱敏
+	LogHeaders bool
+	// LogRequestBody 是否记录请求体
+	LogRequestBody bool
+	// LogResponseBody 是否记录响应体
+	LogResponseBody bool
+	// MaxBodyBytes 记录的请求体与响应体最大字节数，超出部分截断，默认为 DefaultMaxLogBodyBytes
+	MaxBodyBytes int
+	// RedactHeaders 额外需要脱敏的请求头
+	RedactHeaders []string
+	// RedactKeys 请求体与响应体中需要脱敏的 JSON 键，不区分大小写，如 password、token
+	RedactKeys []string
+}
+
+// Logger 日志中间件
+func Logger() nova.HandlerFunc {
+	return LoggerWithConfig(LoggerConfig{})
+}
+
+// LoggerWithConfig 按配置创建结构化访问日志中间件
+// 日志级别按状态码区分：5xx 为 error，4xx 为 warn，其余为 info
+func LoggerWithConfig(config LoggerConfig) nova.HandlerFunc {
+	if config.UserIDKey == "" {
+		config.UserIDKey = UserIDKey
+	}
+	if config.RequestIDHeader == "" {
+		config.RequestIDHeader = nova.RequestIDHeader
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxLogBodyBytes
+	}
+
+	skipPaths := make(map[string]bool, len(config.SkipPaths))
+	for _, path := range config.SkipPaths {
+		skipPaths[path] = true
+	}
+	redactHeaders := make(map[string]bool)
+	for _, name := range append(defaultRedactHeaders, config.RedactHeaders...) {
+		redactHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	redactor := newKeyRedactor(config.RedactKeys)
+
+	return func(c *nova.Context) {
+		if skipPaths[c.Request.URL.Path] || skipPaths[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		// 开始时间
+		start := time.Now()
+		path := c.Request.URL.Path
+		query := c.Request.URL.RawQuery
+
+		// 统计请求体字节数，需要时保留前 MaxBodyBytes 字节
+		var in *countingReader
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			in = &countingReader{ReadCloser: c.Request.Body}
+			if config.LogRequestBody {
+				in.capture = &limitedBuffer{limit: config.MaxBodyBytes}
+			}
+			c.Request.Body = in
+		}
+
+		var out *captureWriter
+		if config.LogResponseBody {
+			out = &captureWriter{ResponseWriter: c.Writer.ResponseWriter, buf: limitedBuffer{limit: config.MaxBodyBytes}}
+			c.Writer.ResponseWriter = out
+			defer func() { c.Writer.ResponseWriter = out.ResponseWriter }()
+		}
+
+		// 处理请求
+		c.Next()
+
+		if config.Skip != nil && config.Skip(c) {
+			return
+		}
+		status := c.Status()
+		if status < http.StatusBadRequest && config.SampleRate > 0 && config.SampleRate < 1 && rand.Float64() >= config.SampleRate {
+			return
+		}
+		log := config.Logger
+		if log == nil {
+			log = logger.Log
+		}
+		if log == nil {
+			return
+		}
+
+		level := zapcore.InfoLevel
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = zapcore.ErrorLevel
+		case status >= http.StatusBadRequest:
+			level = zapcore.WarnLevel
+		}
+		ce := log.Check(level, "http request")
+		if ce == nil {
+			return
+		}
+
+		var bytesIn int64
+		if in != nil {
+			bytesIn = in.n
+		}
+		fields := []zap.Field{
+			zap.Int("status", status),
+			zap.String("method", c.Request.Method),
+			zap.String("path", path),
+			zap.String("route", c.FullPath()),
+			zap.String("ip", c.ClientIP()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.Int64("bytes_in", bytesIn),
+			zap.Int("bytes_out", c.Writer.Size()),
+		}
+		if query != "" {
+			fields = append(fields, zap.String("query", query))
+		}
+		if id := requestID(c, config.RequestIDHeader); id != "" {
+			fields = append(fields, zap.String("request_id", id))
+		}
+		if sc := oteltrace.SpanContextFromContext(c.Request.Context()); sc.HasTraceID() {
+			fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+		}
+		if userID, ok := c.Get(config.UserIDKey); ok && userID != nil {
+			fields = append(fields, zap.Any("user_id", userID))
+		}
+		if config.LogHeaders {
+			fields = append(fields, zap.Any("headers", redactHeaderValues(c.Request.Header, redactHeaders)))
+		}
+		if in != nil && in.capture != nil {
+			fields = append(fields, bodyField("request_body", &in.capture.buf, in.capture.truncated, redactor))
+		}
+		if out != nil {
+			fields = append(fields, bodyField("response_body", &out.buf.buf, out.buf.truncated, redactor))
+		}
+		if err := c.GetError(); err != nil {
+			fields = append(fields, zap.Error(err))
+		}
+		ce.Write(fields...)
+	}
+}
+
+// requestID 获取请求 ID，优先使用 RequestID 中间件保存的值
+func requestID(c *nova.Context, header string) string {
+	if id := c.RequestID(); id != "" {
+		return id
+	}
+	if id := c.Writer.Header().Get(header); id != "" {
+		return id
+	}
+	return c.Request.Header.Get(header)
+}
+
+// redactHeaderValues 复制请求头并将敏感请求头替换为占位值
+func redactHeaderValues(header http.Header, redact map[string]bool) map[string]string {
+	values := make(map[string]string, len(header))
+	for name, v := range header {
+		if redact[http.CanonicalHeaderKey(name)] {
+			values[name] = redacted
+			continue
+		}
+		values[name] = strings.Join(v, ", ")
+	}
+	return values
+}
+
+// bodyField 生成请求体或响应体日志字段，截断时追加 ...
+func bodyField(key string, buf *bytes.Buffer, truncated bool, redactor *keyRedactor) zap.Field {
+	body := redactor.redact(buf.Bytes(), truncated)
+	if truncated {
+		body += "..."
+	}
+	return zap.String(key, body)
+}
+
+// keyRedactor 按 JSON 键脱敏请求体与响应体
+type keyRedactor struct {
+	keys    map[string]bool
+	pattern *regexp.Regexp
+}
+
+// newKeyRedactor 创建 JSON 键脱敏器，keys 为空时不做处理
+func newKeyRedactor(keys []string) *keyRedactor {
+	if len(keys) == 0 {
+		return nil
+	}
+	r := &keyRedactor{keys: make(map[string]bool, len(keys))}
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = true
+		quoted = append(quoted, regexp.QuoteMeta(key))
+	}
+	// 请求体被截断或不是合法 JSON 时按文本匹配 "key": value
+	r.pattern = regexp.MustCompile(`("(?i:` + strings.Join(quoted, "|") + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]*)`)
+	return r
+}
+
+// redact 返回脱敏后的文本
+func (r *keyRedactor) redact(body []byte, truncated bool) string {
+	if r == nil || len(body) == 0 {
+		return string(body)
+	}
+	if !truncated {
+		var v interface{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if dec.Decode(&v) == nil && !dec.More() {
+			if data, err := json.Marshal(r.walk(v)); err == nil {
+				return string(data)
+			}
+		}
+	}
+	return r.pattern.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+}
+
+// walk 递归替换命中的键的值
+func (r *keyRedactor) walk(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if r.keys[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = r.walk(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = r.walk(v[i])
+		}
+	}
+	return v
+}
+
+// limitedBuffer 最多保留 limit 字节的缓冲区
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) {
+	if remain := b.limit - b.buf.Len(); remain < len(p) {
+		if remain > 0 {
+			b.buf.Write(p[:remain])
+		}
+		b.truncated = true
+		return
+	}
+	b.buf.Write(p)
+}
+
+// countingReader 统计已读取的请求体字节数
+type countingReader struct {
+	io.ReadCloser
+	n       int64
+	capture *limitedBuffer
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	if r.capture != nil && n > 0 {
+		r.capture.Write(p[:n])
+	}
+	return n, err
+}
+
+// captureWriter 记录响应体前若干字节的写入器
+type captureWriter struct {
+	http.ResponseWriter
+	buf limitedBuffer
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.buf.Write(p[:n])
+	return n, err
+}
+
+// Flush 实现 http.Flusher 接口
+func (w *captureWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack 实现 http.Hijacker 接口
+func (w *captureWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hj, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hj.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Unwrap 返回底层写入器，供 http.ResponseController 使用
+func (w *captureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xzl-go/nova"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLoggerWithConfig(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	e := nova.NewEngine()
+	e.Use(LoggerWithConfig(LoggerConfig{
+		Logger:          zap.New(core),
+		SkipPaths:       []string{"/health"},
+		LogHeaders:      true,
+		LogRequestBody:  true,
+		LogResponseBody: true,
+		MaxBodyBytes:    64,
+		RedactKeys:      []string{"password"},
+	}))
+	e.POST("/users/:id", func(c *nova.Context) {
+		io.ReadAll(c.Request.Body)
+		c.Set("user_id", uint(7))
+		c.String(http.StatusCreated, `{"token":"x","Password":"y"}`)
+	})
+	e.GET("/fail", func(c *nova.Context) {
+		c.String(http.StatusInternalServerError, "boom")
+	})
+	e.GET("/health", func(c *nova.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		level  zapcore.Level
+		check  func(t *testing.T, fields map[string]interface{})
+	}{
+		{
+			name:   "request",
+			method: http.MethodPost,
+			path:   "/users/1?a=b",
+			body:   `{"name":"tom","password":"secret"}`,
+			level:  zapcore.InfoLevel,
+			check: func(t *testing.T, fields map[string]interface{}) {
+				want := map[string]interface{}{
+					"status":        int64(http.StatusCreated),
+					"route":         "/users/:id",
+					"query":         "a=b",
+					"request_id":    "req-1",
+					"user_id":       uint64(7),
+					"bytes_in":      int64(34),
+					"bytes_out":     int64(28),
+					"request_body":  `{"name":"tom","password":"[REDACTED]"}`,
+					"response_body": `{"Password":"[REDACTED]","token":"x"}`,
+				}
+				for k, v := range want {
+					if fields[k] != v {
+						t.Errorf("%s = %#v, want %#v", k, fields[k], v)
+					}
+				}
+				headers := fields["headers"].(map[string]string)
+				if headers["Authorization"] != redacted || headers["X-Request-Id"] != "req-1" {
+					t.Errorf("headers = %v", headers)
+				}
+			},
+		},
+		{name: "server error", method: http.MethodGet, path: "/fail", level: zapcore.ErrorLevel},
+		{name: "skip", method: http.MethodGet, path: "/health"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.TakeAll()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer secret")
+			req.Header.Set("X-Request-ID", "req-1")
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			entries := logs.TakeAll()
+			if tt.check == nil && tt.level == zapcore.InfoLevel {
+				if len(entries) != 0 {
+					t.Fatalf("logged %d entries, want 0", len(entries))
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("logged %d entries, want 1", len(entries))
+			}
+			if entries[0].Level != tt.level {
+				t.Errorf("level = %v, want %v", entries[0].Level, tt.level)
+			}
+			if tt.check != nil {
+				tt.check(t, entries[0].ContextMap())
+			}
+		})
+	}
+}
+
+func TestKeyRedactorTruncated(t *testing.T) {
+	r := newKeyRedactor([]string{"token"})
+	got := r.redact([]byte(`{"a":1,"Token":"abcdef`), true)
+	if want := `{"a":1,"Token":"[REDACTED]"`; got != want {
+		t.Errorf("redact = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/xzl-go/nova"
+)
+
+// RequestIDKey Context 中保存请求 ID 的键
+const RequestIDKey = "request_id"
+
+// maxRequestIDLength 沿用上游请求 ID 时允许的最大长度
+const maxRequestIDLength = 128
+
+// RequestIDConfig 请求 ID 配置
+type RequestIDConfig struct {
+	// Header 读取与回写请求 ID 的请求头，默认为 X-Request-ID
+	Header string
+	// Generator 生成请求 ID，默认为 UUIDv7
+	Generator func() string
+	// IgnoreIncoming 是否忽略上游传入的请求 ID，总是重新生成
+	IgnoreIncoming bool
+}
+
+// RequestID 请求 ID 中间件，使用 UUIDv7 生成请求 ID
+func RequestID() nova.HandlerFunc {
+	return RequestIDWithConfig(RequestIDConfig{})
+}
+
+// RequestIDWithConfig 按配置创建请求 ID 中间件
+// 上游传入合法的请求 ID 时沿用，否则重新生成；请求 ID 写入响应头，并保存到 Context 与请求 context，
+// 可通过 c.RequestID()、nova.RequestIDFromContext 读取，logger.Ctx 输出的日志与 HTTPClient.WithContext 发出的请求会自动携带
+func RequestIDWithConfig(config RequestIDConfig) nova.HandlerFunc {
+	if config.Header == "" {
+		config.Header = nova.RequestIDHeader
+	}
+	if config.Generator == nil {
+		config.Generator = UUIDv7
+	}
+
+	return func(c *nova.Context) {
+		id := ""
+		if !config.IgnoreIncoming {
+			id = c.Request.Header.Get(config.Header)
+		}
+		if !validRequestID(id) {
+			id = config.Generator()
+			c.Request.Header.Set(config.Header, id)
+		}
+
+		c.Header(config.Header, id)
+		c.Set(RequestIDKey, id)
+		c.Request = c.Request.WithContext(nova.ContextWithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// validRequestID 检查上游请求 ID，只接受长度受限的可打印 ASCII 字符，避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// UUIDv7 生成 RFC 9562 UUIDv7，高 48 位为毫秒时间戳，按时间有序
+func UUIDv7() string {
+	var b [16]byte
+	fillTimeRandom(&b)
+	b[6] = b[6]&0x0f | 0x70 // 版本 7
+	b[8] = b[8]&0x3f | 0x80 // RFC 9562 变体
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf[:])
+}
+
+// crockford ULID 使用的 Crockford Base32 字母表
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID 生成 26 个字符的 ULID，高 48 位为毫秒时间戳，按时间有序
+func ULID() string {
+	var b [16]byte
+	fillTimeRandom(&b)
+
+	// 128 位数据前补 2 个 0 位，按 5 位一组编码为 26 个字符
+	var buf [26]byte
+	for i := range buf {
+		var v byte
+		for j := 0; j < 5; j++ {
+			bit := i*5 + j - 2
+			v <<= 1
+			if bit >= 0 && b[bit/8]&(0x80>>(bit%8)) != 0 {
+				v |= 1
+			}
+		}
+		buf[i] = crockford[v]
+	}
+	return string(buf[:])
+}
+
+// fillTimeRandom 前 6 字节写入毫秒时间戳，其余字节填充随机数
+func fillTimeRandom(b *[16]byte) {
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(time.Now().UnixMilli()))
+	copy(b[:6], ts[2:])
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic("nova: failed to read random bytes: " + err.Error())
+	}
+}
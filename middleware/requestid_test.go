@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/xzl-go/nova"
+	"github.com/xzl-go/nova/logger"
+)
+
+func TestRequestIDWithConfig(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidPattern := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+
+	tests := []struct {
+		name     string
+		config   RequestIDConfig
+		incoming string
+		want     *regexp.Regexp
+	}{
+		{name: "generate uuidv7", want: uuidPattern},
+		{name: "generate ulid", config: RequestIDConfig{Generator: ULID}, want: ulidPattern},
+		{name: "keep incoming", incoming: "upstream-1", want: regexp.MustCompile(`^upstream-1$`)},
+		{name: "reject invalid incoming", incoming: "bad id\n", want: uuidPattern},
+		{name: "ignore incoming", config: RequestIDConfig{IgnoreIncoming: true}, incoming: "upstream-1", want: uuidPattern},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext, fromStore string
+			var fields int
+			e := nova.NewEngine()
+			e.Use(RequestIDWithConfig(tt.config))
+			e.GET("/", func(c *nova.Context) {
+				fromContext = c.RequestID()
+				v, _ := c.Get(RequestIDKey)
+				fromStore, _ = v.(string)
+				fields = len(logger.ContextFields(c.Request.Context()))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set("X-Request-ID", tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+
+			id := w.Header().Get("X-Request-ID")
+			if !tt.want.MatchString(id) {
+				t.Errorf("request id = %q, want match %s", id, tt.want)
+			}
+			if fromContext != id || fromStore != id {
+				t.Errorf("context id = %q, store id = %q, want %q", fromContext, fromStore, id)
+			}
+			if fields != 1 {
+				t.Errorf("logger context fields = %d, want 1", fields)
+			}
+		})
+	}
+}
+
+func TestRequestIDGeneratorsUnique(t *testing.T) {
+	for name, gen := range map[string]func() string{"uuidv7": UUIDv7, "ulid": ULID} {
+		seen := make(map[string]bool)
+		for i := 0; i < 10000; i++ {
+			id := gen()
+			if seen[id] {
+				t.Fatalf("%s generated duplicate id %s", name, id)
+			}
+			seen[id] = true
+		}
+	}
+}
@@ -6,22 +6,9 @@ import (
 	"github.com/xzl-go/nova"
 )
 
-// Security 安全中间件
+// Security 安全中间件，设置常用安全响应头，跨域请使用 CORS 或 CORSWithConfig
 func Security() nova.HandlerFunc {
 	return func(c *nova.Context) {
-		// CORS
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-		c.Header("Access-Control-Expose-Headers", "Content-Length")
-		c.Header("Access-Control-Max-Age", "86400")
-
-		// 处理 OPTIONS 请求
-		if c.Request.Method == "OPTIONS" {
-			c.Abort()
-			return
-		}
-
 		// XSS 防护
 		c.Header("X-XSS-Protection", "1; mode=block")
 		c.Header("X-Content-Type-Options", "nosniff")
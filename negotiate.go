@@ -0,0 +1,85 @@
+package nova
+
+import (
+	"encoding/xml"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xzl-go/nova/i18n"
+	"github.com/xzl-go/nova/validator"
+)
+
+// 内容协商支持的格式
+const (
+	MIMEJSON = "application/json"
+	MIMEXML  = "application/xml"
+	MIMEXML2 = "text/xml"
+)
+
+// XML 返回XML响应
+func (c *Context) XML(code int, data interface{}) {
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.Writer.WriteHeader(code)
+	xml.NewEncoder(c.Writer).Encode(data)
+}
+
+// Negotiate 根据 Accept 请求头选择 JSON 或 XML 输出，默认 JSON
+func (c *Context) Negotiate(code int, data interface{}) {
+	switch c.NegotiateFormat(MIMEJSON, MIMEXML, MIMEXML2) {
+	case MIMEXML, MIMEXML2:
+		c.XML(code, data)
+	default:
+		c.JSON(code, data)
+	}
+}
+
+// NegotiateFormat 按 Accept 请求头的 q 值从 offered 中选择最合适的格式
+// 未携带 Accept 或无匹配时返回 offered[0]
+func (c *Context) NegotiateFormat(offered ...string) string {
+	if len(offered) == 0 {
+		return ""
+	}
+	accept := c.Request.Header.Get("Accept")
+	if accept == "" {
+		return offered[0]
+	}
+
+	type acceptRange struct {
+		mediaType string
+		q         float64
+	}
+	ranges := make([]acceptRange, 0, 4)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	for _, r := range ranges {
+		for _, o := range offered {
+			if r.mediaType == o || r.mediaType == "*/*" ||
+				(strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(o, strings.TrimSuffix(r.mediaType, "*"))) {
+				return o
+			}
+		}
+	}
+	return offered[0]
+}
+
+// Language 按 Accept-Language 请求头从校验消息支持的语言中选择请求语言，无匹配时返回默认语言
+func (c *Context) Language() i18n.Language {
+	return validator.Translator().MatchLanguage(c.Request.Header.Get("Accept-Language"))
+}
@@ -3,6 +3,7 @@ package nova
 import (
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -36,7 +37,12 @@ func GetContext(w http.ResponseWriter, r *http.Request) *Context {
 }
 
 // PutContext 将 Context 放回对象池
+// 调试模式下 Context 不再复用，而是标记为已释放，之后的访问会触发 panic
 func PutContext(c *Context) {
+	if IsDebugging() {
+		atomic.StoreInt32(&c.released, 1)
+		return
+	}
 	c.reset()
 	PutResponseWriter(c.Writer)
 	contextPool.Put(c)
@@ -47,6 +53,7 @@ func GetResponseWriter(w http.ResponseWriter) *ResponseWriter {
 	rw := responseWriterPool.Get().(*ResponseWriter)
 	rw.ResponseWriter = w
 	rw.Status = http.StatusOK
+	rw.size = noWritten
 	return rw
 }
 
@@ -54,5 +61,6 @@ func GetResponseWriter(w http.ResponseWriter) *ResponseWriter {
 func PutResponseWriter(rw *ResponseWriter) {
 	rw.ResponseWriter = nil
 	rw.Status = 0
+	rw.size = noWritten
 	responseWriterPool.Put(rw)
 }
@@ -0,0 +1,124 @@
+package nova
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"syscall"
+
+	"github.com/xzl-go/nova/logger"
+	"go.uber.org/zap"
+)
+
+// PanicError 处理链中 panic 转换成的错误，记录 panic 值与调用栈
+type PanicError struct {
+	Value      interface{} // panic 值
+	Stack      []byte      // 调用栈，DisableStack 时为空
+	BrokenPipe bool        // 是否因客户端断开连接导致，此时不再写响应
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap panic 值为 error 时返回该错误
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// PanicSink 接收 panic 报告，如上报到错误追踪服务，在写响应之前调用
+type PanicSink func(c *Context, err *PanicError)
+
+// RecoveryConfig 恢复配置
+type RecoveryConfig struct {
+	// Logger 输出日志的 zap 实例，nil 时使用 logger.Log，二者均为 nil 时不输出
+	Logger *zap.Logger
+	// DisableStack 是否不采集调用栈
+	DisableStack bool
+	// Sinks panic 报告接收方
+	Sinks []PanicSink
+	// Handler 自定义响应，nil 时通过 Engine.ErrorHandler 渲染为 500；客户端已断开连接时不调用
+	Handler ErrorHandlerFunc
+}
+
+// Recovery 恢复中间件，使用默认配置
+func Recovery() HandlerFunc {
+	return RecoveryWithConfig(RecoveryConfig{})
+}
+
+// RecoveryWithConfig 按配置创建恢复中间件
+// panic 值为 http.ErrAbortHandler 时原样重新 panic，由 net/http 中断连接且不记录日志；
+// 客户端断开连接（broken pipe、connection reset）导致的 panic 只记录日志，不再尝试写响应
+func RecoveryWithConfig(config RecoveryConfig) HandlerFunc {
+	return func(c *Context) {
+		defer func() {
+			if v := recover(); v != nil {
+				config.recover(c, v)
+			}
+		}()
+		c.Next()
+	}
+}
+
+// recover 处理已捕获的 panic 值
+func (config *RecoveryConfig) recover(c *Context, v interface{}) {
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+
+	perr := &PanicError{Value: v}
+	if err, ok := v.(error); ok {
+		perr.BrokenPipe = isBrokenPipe(err)
+	}
+	if !config.DisableStack {
+		perr.Stack = debug.Stack()
+	}
+
+	log := config.Logger
+	if log == nil {
+		log = logger.Log
+	}
+	if log != nil {
+		fields := []zap.Field{
+			zap.Any("error", v),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Bool("broken_pipe", perr.BrokenPipe),
+		}
+		fields = append(fields, logger.ContextFields(c.Request.Context())...)
+		if perr.Stack != nil {
+			fields = append(fields, zap.ByteString("stack", perr.Stack))
+		}
+		log.Error("panic recovered", fields...)
+	}
+	for _, sink := range config.Sinks {
+		sink(c, perr)
+	}
+
+	c.Error(perr)
+	c.Abort()
+	if perr.BrokenPipe || c.Writer.Written() {
+		return
+	}
+	if config.Handler != nil {
+		config.Handler(c, perr)
+		return
+	}
+	if c.engine != nil {
+		c.engine.handleError(c, perr)
+		return
+	}
+	DefaultErrorHandler(c, perr)
+}
+
+// isBrokenPipe 检查错误是否由客户端断开连接导致
+func isBrokenPipe(err error) bool {
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer")
+}
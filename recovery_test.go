@@ -0,0 +1,90 @@
+package nova
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestRecovery(t *testing.T) {
+	var reported []*PanicError
+	e := NewEngine()
+	e.Recovery.Sinks = []PanicSink{func(c *Context, err *PanicError) {
+		reported = append(reported, err)
+	}}
+	e.GET("/panic", func(c *Context) {
+		panic("boom")
+	})
+	e.GET("/pipe", func(c *Context) {
+		panic(fmt.Errorf("write tcp: %w", syscall.EPIPE))
+	})
+	e.GET("/custom", RecoveryWithConfig(RecoveryConfig{
+		DisableStack: true,
+		Handler: func(c *Context, err error) {
+			c.String(http.StatusServiceUnavailable, "custom")
+		},
+	}), func(c *Context) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		path       string
+		status     int
+		body       string
+		brokenPipe bool
+		stack      bool
+	}{
+		{path: "/panic", status: http.StatusInternalServerError, body: `"code":1001`, stack: true},
+		{path: "/pipe", status: http.StatusOK, brokenPipe: true, stack: true},
+		{path: "/custom", status: http.StatusServiceUnavailable, body: "custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			reported = nil
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.body)
+			}
+			if strings.Contains(w.Body.String(), "boom") {
+				t.Errorf("body leaks panic value: %s", w.Body.String())
+			}
+			if tt.path == "/custom" {
+				if len(reported) != 0 {
+					t.Errorf("engine sinks called for middleware recovery")
+				}
+				return
+			}
+			if len(reported) != 1 {
+				t.Fatalf("reported %d panics, want 1", len(reported))
+			}
+			if reported[0].BrokenPipe != tt.brokenPipe {
+				t.Errorf("BrokenPipe = %v, want %v", reported[0].BrokenPipe, tt.brokenPipe)
+			}
+			if (len(reported[0].Stack) > 0) != tt.stack {
+				t.Errorf("stack captured = %v, want %v", len(reported[0].Stack) > 0, tt.stack)
+			}
+		})
+	}
+}
+
+func TestRecoveryAbortHandler(t *testing.T) {
+	e := NewEngine()
+	e.GET("/abort", func(c *Context) {
+		panic(http.ErrAbortHandler)
+	})
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Fatalf("recover = %v, want http.ErrAbortHandler", v)
+		}
+	}()
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+}
@@ -0,0 +1,34 @@
+package nova
+
+import (
+	"context"
+
+	"github.com/xzl-go/nova/logger"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader 传递请求 ID 的请求头与响应头
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey context 中保存请求 ID 的键
+type requestIDKey struct{}
+
+// ContextWithRequestID 返回保存了请求 ID 的 context，同时将 request_id 附加到 logger.Ctx 的日志字段
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return logger.NewContext(ctx, zap.String("request_id", id))
+}
+
+// RequestIDFromContext 获取 context 中的请求 ID，不存在时返回空字符串
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID 获取当前请求的请求 ID，由 middleware.RequestID 生成或沿用上游传入的值
+func (c *Context) RequestID() string {
+	return RequestIDFromContext(c.Request.Context())
+}
@@ -0,0 +1,39 @@
+package nova
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClientRequestID(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(RequestIDHeader)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(0)
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+
+	tests := []struct {
+		name   string
+		client *HTTPClient
+		want   string
+	}{
+		{name: "without context", client: client, want: ""},
+		{name: "with context", client: client.WithContext(ctx), want: "req-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			if _, err := tt.client.Get(srv.URL); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("X-Request-ID = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
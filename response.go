@@ -0,0 +1,179 @@
+package nova
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidRedirectCode 非法的重定向状态码
+	ErrInvalidRedirectCode = errors.New("nova: invalid redirect status code")
+	// ErrUnsafeRedirect 重定向目标不在允许的主机列表中
+	ErrUnsafeRedirect = errors.New("nova: unsafe redirect location")
+)
+
+// Redirect 重定向到指定地址
+// 相对路径总是允许；绝对地址仅允许当前主机或 Engine.RedirectAllowedHosts 中的主机，防止开放重定向
+func (c *Context) Redirect(code int, location string) error {
+	c.checkReleased()
+	if (code < http.StatusMultipleChoices || code > http.StatusPermanentRedirect) && code != http.StatusCreated {
+		return ErrInvalidRedirectCode
+	}
+	if !c.isSafeRedirect(location) {
+		return ErrUnsafeRedirect
+	}
+	http.Redirect(c.Writer, c.Request, location, code)
+	return nil
+}
+
+// isSafeRedirect 校验重定向地址
+func (c *Context) isSafeRedirect(location string) bool {
+	// 反斜杠和控制字符会被部分浏览器解释为 "//"，一律拒绝
+	if strings.ContainsAny(location, "\\\r\n\t") {
+		return false
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" && u.Host == "" {
+		return true
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return false
+	}
+	if strings.EqualFold(stripPort(c.Request.Host), host) {
+		return true
+	}
+	if c.engine == nil {
+		return false
+	}
+	for _, allowed := range c.engine.RedirectAllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if allowed == host {
+			return true
+		}
+		if strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// File 返回文件内容，支持 Range 与条件请求
+func (c *Context) File(path string) {
+	c.checkReleased()
+	http.ServeFile(c.Writer, c.Request, path)
+}
+
+// FileFromFS 从文件系统返回文件内容，支持 Range 与条件请求
+func (c *Context) FileFromFS(path string, fsys fs.FS) {
+	c.checkReleased()
+	http.ServeFileFS(c.Writer, c.Request, fsys, strings.TrimPrefix(path, "/"))
+}
+
+// Attachment 以附件形式下载文件，filename 为空时使用文件名
+func (c *Context) Attachment(path, filename string) {
+	if filename == "" {
+		filename = filepath.Base(path)
+	}
+	c.Header("Content-Disposition", contentDisposition("attachment", filename))
+	c.File(path)
+}
+
+// DataFromReader 从 reader 输出响应
+// reader 实现 io.ReadSeeker 且状态码为 200 时使用 http.ServeContent，支持 Range 与条件请求；
+// 可通过 headers 中的 Last-Modified 参与条件请求判断
+func (c *Context) DataFromReader(code int, contentLength int64, contentType string, reader io.Reader, headers map[string]string) error {
+	c.checkReleased()
+	header := c.Writer.Header()
+	for k, v := range headers {
+		header.Set(k, v)
+	}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	if rs, ok := reader.(io.ReadSeeker); ok && code == http.StatusOK {
+		var modtime time.Time
+		if lm := header.Get("Last-Modified"); lm != "" {
+			if t, err := http.ParseTime(lm); err == nil {
+				modtime = t
+			}
+		}
+		http.ServeContent(c.Writer, c.Request, "", modtime, rs)
+		return nil
+	}
+
+	if contentLength >= 0 {
+		header.Set("Content-Length", fmt.Sprintf("%d", contentLength))
+	}
+	c.Writer.WriteHeader(code)
+	_, err := io.Copy(c.Writer, reader)
+	return err
+}
+
+// contentDisposition 按 RFC 6266 生成 Content-Disposition，非 ASCII 文件名使用 filename* 编码
+func contentDisposition(disposition, filename string) string {
+	fallback := make([]byte, 0, len(filename))
+	ascii := true
+	for i := 0; i < len(filename); i++ {
+		b := filename[i]
+		switch {
+		case b >= 0x80:
+			ascii = false
+			// 多字节字符只在首字节处写入一个占位符
+			if b >= 0xC0 {
+				fallback = append(fallback, '_')
+			}
+		case b < 0x20 || b == 0x7f || b == '"' || b == '\\':
+			ascii = false
+			fallback = append(fallback, '_')
+		default:
+			fallback = append(fallback, b)
+		}
+	}
+	if ascii {
+		return disposition + `; filename="` + filename + `"`
+	}
+	return disposition + `; filename="` + string(fallback) + `"; filename*=UTF-8''` + encodeRFC5987(filename)
+}
+
+// stripPort 去除地址中的端口
+func stripPort(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.Trim(hostport, "[]")
+}
+
+// encodeRFC5987 按 RFC 5987 attr-char 规则进行百分号编码
+func encodeRFC5987(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') || ('0' <= ch && ch <= '9') ||
+			strings.IndexByte("!#$&+-.^_`|~", ch) >= 0 {
+			b.WriteByte(ch)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[ch>>4])
+		b.WriteByte(hex[ch&0x0f])
+	}
+	return b.String()
+}
@@ -0,0 +1,214 @@
+package nova
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试重定向只允许相对路径、当前主机与白名单主机
+func TestRedirect(t *testing.T) {
+	e := NewEngine()
+	e.RedirectAllowedHosts = []string{"*.example.com", "trusted.org"}
+	e.GET("/go", func(c *Context) {
+		if err := c.Redirect(http.StatusFound, c.Request.URL.Query().Get("to")); err != nil {
+			c.String(http.StatusBadRequest, "%v", err)
+		}
+	})
+
+	tests := []struct {
+		location string
+		want     string // 期望的 Location，为空表示拒绝
+	}{
+		{"/dashboard", "/dashboard"},
+		{"/path?next=1", "/path?next=1"},
+		{"https://api.test/home", "https://api.test/home"},
+		{"https://a.example.com/x", "https://a.example.com/x"},
+		{"https://A.B.Example.com", "https://A.B.Example.com"},
+		{"http://trusted.org:8080/", "http://trusted.org:8080/"},
+		{"///evil.com", "/evil.com"},
+		{"//evil.com", ""},
+		{"//evil.com/%2F..", ""},
+		{"/\\evil.com", ""},
+		{"\\\\evil.com", ""},
+		{"/\tevil.com", ""},
+		{"https:evil.com", ""},
+		{"https:/evil.com", ""},
+		{"https://evil.com", ""},
+		{"https://trusted.org.evil.com", ""},
+		{"https://a.example.com.evil.com", ""},
+		{"https://evilexample.com", ""},
+		{"https://example.com", ""},
+		{"https://a.example.com@evil.com", ""},
+		{"javascript:alert(1)", ""},
+		{"ftp://a.example.com", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/go", nil)
+		r.Host = "api.test"
+		q := r.URL.Query()
+		q.Set("to", tt.location)
+		r.URL.RawQuery = q.Encode()
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+
+		if tt.want == "" {
+			if w.Code != http.StatusBadRequest || w.Header().Get("Location") != "" {
+				t.Errorf("%q: got %d Location %q, want rejected", tt.location, w.Code, w.Header().Get("Location"))
+			}
+			continue
+		}
+		if w.Code != http.StatusFound || w.Header().Get("Location") != tt.want {
+			t.Errorf("%q: got %d Location %q, want %q", tt.location, w.Code, w.Header().Get("Location"), tt.want)
+		}
+	}
+}
+
+// 测试非 ASCII 与特殊字符文件名按 RFC 6266 编码
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"report.pdf", `attachment; filename="report.pdf"`},
+		{"报告 2024.pdf", `attachment; filename="__ 2024.pdf"; filename*=UTF-8''%E6%8A%A5%E5%91%8A%202024.pdf`},
+		{"résumé.txt", `attachment; filename="r_sum_.txt"; filename*=UTF-8''r%C3%A9sum%C3%A9.txt`},
+		{`a"b\c.txt`, `attachment; filename="a_b_c.txt"; filename*=UTF-8''a%22b%5Cc.txt`},
+		{"line\r\nbreak.txt", `attachment; filename="line__break.txt"; filename*=UTF-8''line%0D%0Abreak.txt`},
+	}
+	for _, tt := range tests {
+		if got := contentDisposition("attachment", tt.filename); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.filename, got, tt.want)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "data.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngine()
+	e.GET("/download", func(c *Context) {
+		c.Attachment(path, "数据.txt")
+	})
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/download", nil))
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="__.txt"; filename*=UTF-8''%E6%95%B0%E6%8D%AE.txt` {
+		t.Errorf("Content-Disposition = %s", got)
+	}
+	if w.Body.String() != "hello" {
+		t.Errorf("body = %q, want hello", w.Body.String())
+	}
+}
+
+// 测试 DataFromReader 对 Range 与条件请求的处理
+func TestDataFromReader(t *testing.T) {
+	modified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := NewEngine()
+	e.GET("/seek", func(c *Context) {
+		c.DataFromReader(http.StatusOK, 10, "text/plain", bytes.NewReader([]byte("0123456789")),
+			map[string]string{"Last-Modified": modified.Format(http.TimeFormat)})
+	})
+	e.GET("/stream", func(c *Context) {
+		c.DataFromReader(http.StatusOK, 10, "text/plain", io.MultiReader(strings.NewReader("0123456789")), nil)
+	})
+	e.GET("/created", func(c *Context) {
+		c.DataFromReader(http.StatusCreated, -1, "text/plain", strings.NewReader("0123456789"), nil)
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		headers  map[string]string
+		wantCode int
+		wantBody string
+		want     map[string]string
+	}{
+		{
+			name:     "full",
+			path:     "/seek",
+			wantCode: http.StatusOK,
+			wantBody: "0123456789",
+			want:     map[string]string{"Accept-Ranges": "bytes", "Content-Length": "10", "Content-Type": "text/plain"},
+		},
+		{
+			name:     "range",
+			path:     "/seek",
+			headers:  map[string]string{"Range": "bytes=2-4"},
+			wantCode: http.StatusPartialContent,
+			wantBody: "234",
+			want:     map[string]string{"Content-Range": "bytes 2-4/10", "Content-Length": "3"},
+		},
+		{
+			name:     "suffix range",
+			path:     "/seek",
+			headers:  map[string]string{"Range": "bytes=-3"},
+			wantCode: http.StatusPartialContent,
+			wantBody: "789",
+			want:     map[string]string{"Content-Range": "bytes 7-9/10"},
+		},
+		{
+			name:     "unsatisfiable range",
+			path:     "/seek",
+			headers:  map[string]string{"Range": "bytes=20-30"},
+			wantCode: http.StatusRequestedRangeNotSatisfiable,
+			want:     map[string]string{"Content-Range": "bytes */10"},
+		},
+		{
+			name:     "if-range mismatch",
+			path:     "/seek",
+			headers:  map[string]string{"Range": "bytes=2-4", "If-Range": modified.Add(-time.Hour).Format(http.TimeFormat)},
+			wantCode: http.StatusOK,
+			wantBody: "0123456789",
+		},
+		{
+			name:     "not modified",
+			path:     "/seek",
+			headers:  map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:     "non-seekable ignores range",
+			path:     "/stream",
+			headers:  map[string]string{"Range": "bytes=2-4"},
+			wantCode: http.StatusOK,
+			wantBody: "0123456789",
+			want:     map[string]string{"Content-Length": "10", "Content-Range": ""},
+		},
+		{
+			name:     "non-200 ignores range",
+			path:     "/created",
+			headers:  map[string]string{"Range": "bytes=2-4"},
+			wantCode: http.StatusCreated,
+			wantBody: "0123456789",
+			want:     map[string]string{"Content-Length": "", "Content-Range": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			for k, v := range tt.want {
+				if got := w.Header().Get(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package nova
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// noWritten 响应尚未写入时的 size 值
+const noWritten = -1
+
+var (
+	_ http.Hijacker = (*ResponseWriter)(nil)
+	_ http.Flusher  = (*ResponseWriter)(nil)
+	_ http.Pusher   = (*ResponseWriter)(nil)
+	_ io.ReaderFrom = (*ResponseWriter)(nil)
+)
+
+// ResponseWriter 自定义响应写入器
+// 当底层写入器支持时，透明地提供 http.Hijacker、http.Flusher、http.Pusher 与 io.ReaderFrom，
+// 并实现 Unwrap 以兼容 http.ResponseController
+type ResponseWriter struct {
+	http.ResponseWriter
+	Status int
+	size   int
+}
+
+// newResponseWriter 创建响应写入器
+func newResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	return &ResponseWriter{ResponseWriter: w, Status: http.StatusOK, size: noWritten}
+}
+
+// WriteHeader 重写 WriteHeader 方法，重复调用时忽略并在调试模式下输出警告
+func (w *ResponseWriter) WriteHeader(code int) {
+	if w.Written() {
+		debugPrint("[WARNING] headers were already written, status code %d is ignored (current %d)", code, w.Status)
+		return
+	}
+	w.Status = code
+	w.size = 0
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write 写入响应体，未写响应头时先写入当前状态码
+func (w *ResponseWriter) Write(data []byte) (int, error) {
+	w.writeHeaderNow()
+	n, err := w.ResponseWriter.Write(data)
+	w.size += n
+	return n, err
+}
+
+// WriteString 写入字符串响应体
+func (w *ResponseWriter) WriteString(s string) (int, error) {
+	w.writeHeaderNow()
+	n, err := io.WriteString(w.ResponseWriter, s)
+	w.size += n
+	return n, err
+}
+
+// Status 获取状态码
+func (w *ResponseWriter) GetStatus() int {
+	return w.Status
+}
+
+// Written 响应头是否已写入
+func (w *ResponseWriter) Written() bool {
+	return w.size != noWritten
+}
+
+// Size 已写入的响应体字节数
+func (w *ResponseWriter) Size() int {
+	if w.size < 0 {
+		return 0
+	}
+	return w.size
+}
+
+// Flush 实现 http.Flusher 接口，将缓冲数据立即发送给客户端
+func (w *ResponseWriter) Flush() {
+	w.writeHeaderNow()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack 实现 http.Hijacker 接口，用于 WebSocket 等协议升级
+func (w *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("nova: response writer does not implement http.Hijacker: %w", http.ErrNotSupported)
+	}
+	if w.size < 0 {
+		w.size = 0
+	}
+	return hj.Hijack()
+}
+
+// Push 实现 http.Pusher 接口，用于 HTTP/2 服务端推送
+func (w *ResponseWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// ReadFrom 实现 io.ReaderFrom 接口，底层支持时可走 sendfile 等零拷贝路径
+func (w *ResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.writeHeaderNow()
+	var n int64
+	var err error
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(r)
+	} else {
+		n, err = io.Copy(writerOnly{w.ResponseWriter}, r)
+	}
+	w.size += int(n)
+	return n, err
+}
+
+// Unwrap 返回底层写入器，供 http.ResponseController 使用
+func (w *ResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writeHeaderNow 响应头未写入时立即写入
+func (w *ResponseWriter) writeHeaderNow() {
+	if !w.Written() {
+		w.WriteHeader(w.Status)
+	}
+}
+
+// writerOnly 隐藏 ReadFrom 方法，避免 io.Copy 递归调用
+type writerOnly struct {
+	io.Writer
+}
@@ -0,0 +1,143 @@
+package nova
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fullWriter 实现全部可选接口的底层写入器
+type fullWriter struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+	pushed   string
+	readFrom bool
+	deadline time.Time
+}
+
+func (w *fullWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return nil, nil, nil
+}
+
+func (w *fullWriter) Push(target string, _ *http.PushOptions) error {
+	w.pushed = target
+	return nil
+}
+
+func (w *fullWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.readFrom = true
+	return io.Copy(w.ResponseRecorder, r)
+}
+
+func (w *fullWriter) SetWriteDeadline(t time.Time) error {
+	w.deadline = t
+	return nil
+}
+
+// plainWriter 只实现 http.ResponseWriter 的底层写入器
+type plainWriter struct {
+	header http.Header
+	body   strings.Builder
+	code   int
+}
+
+func (w *plainWriter) Header() http.Header         { return w.header }
+func (w *plainWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+func (w *plainWriter) WriteHeader(code int)        { w.code = code }
+
+// 测试状态码与写入字节数的记录
+func TestResponseWriterBookkeeping(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newResponseWriter(rec)
+	if w.Written() || w.Size() != 0 || w.Status != http.StatusOK {
+		t.Fatalf("initial state: written=%v size=%d status=%d", w.Written(), w.Size(), w.Status)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(http.StatusTeapot)
+	if w.Status != http.StatusCreated || rec.Code != http.StatusCreated {
+		t.Fatalf("double WriteHeader: status=%d recorded=%d, want 201", w.Status, rec.Code)
+	}
+	if !w.Written() || w.Size() != 0 {
+		t.Fatalf("after WriteHeader: written=%v size=%d", w.Written(), w.Size())
+	}
+
+	w.Write([]byte("hello"))
+	w.WriteString(", world")
+	if w.Size() != 12 || rec.Body.String() != "hello, world" {
+		t.Fatalf("size=%d body=%q", w.Size(), rec.Body.String())
+	}
+
+	// 未显式写响应头时，首次写入使用当前状态码
+	rec = httptest.NewRecorder()
+	w = newResponseWriter(rec)
+	w.Status = http.StatusAccepted
+	w.WriteString("ok")
+	if rec.Code != http.StatusAccepted || w.Size() != 2 {
+		t.Fatalf("implicit header: code=%d size=%d", rec.Code, w.Size())
+	}
+}
+
+// 测试可选接口透传到底层写入器
+func TestResponseWriterPassthrough(t *testing.T) {
+	full := &fullWriter{ResponseRecorder: httptest.NewRecorder()}
+	w := newResponseWriter(full)
+
+	if _, _, err := w.Hijack(); err != nil || !full.hijacked {
+		t.Fatalf("hijack: err=%v hijacked=%v", err, full.hijacked)
+	}
+	if !w.Written() {
+		t.Fatal("hijacked writer should be marked written")
+	}
+
+	w = newResponseWriter(full)
+	w.Flush()
+	if !full.Flushed || !w.Written() {
+		t.Fatalf("flush: flushed=%v written=%v", full.Flushed, w.Written())
+	}
+	if err := w.Push("/app.js", nil); err != nil || full.pushed != "/app.js" {
+		t.Fatalf("push: err=%v target=%q", err, full.pushed)
+	}
+	n, err := w.ReadFrom(strings.NewReader("abc"))
+	if err != nil || n != 3 || !full.readFrom || w.Size() != 3 {
+		t.Fatalf("read from: n=%d err=%v readFrom=%v size=%d", n, err, full.readFrom, w.Size())
+	}
+
+	plain := &plainWriter{header: http.Header{}}
+	w = newResponseWriter(plain)
+	if _, _, err := w.Hijack(); !errors.Is(err, http.ErrNotSupported) {
+		t.Fatalf("hijack on plain writer: err=%v", err)
+	}
+	if err := w.Push("/app.js", nil); !errors.Is(err, http.ErrNotSupported) {
+		t.Fatalf("push on plain writer: err=%v", err)
+	}
+	w.Flush()
+	if n, err := w.ReadFrom(strings.NewReader("abcd")); err != nil || n != 4 || plain.body.String() != "abcd" || w.Size() != 4 {
+		t.Fatalf("read from plain writer: n=%d err=%v body=%q size=%d", n, err, plain.body.String(), w.Size())
+	}
+}
+
+// 测试 http.ResponseController 通过 Unwrap 访问底层写入器
+func TestResponseWriterUnwrap(t *testing.T) {
+	full := &fullWriter{ResponseRecorder: httptest.NewRecorder()}
+	rc := http.NewResponseController(newResponseWriter(full))
+	deadline := time.Now().Add(time.Minute)
+	if err := rc.SetWriteDeadline(deadline); err != nil || !full.deadline.Equal(deadline) {
+		t.Fatalf("set write deadline: err=%v deadline=%v", err, full.deadline)
+	}
+	if err := rc.Flush(); err != nil || !full.Flushed {
+		t.Fatalf("flush: err=%v flushed=%v", err, full.Flushed)
+	}
+
+	rc = http.NewResponseController(newResponseWriter(&plainWriter{header: http.Header{}}))
+	if err := rc.SetWriteDeadline(deadline); !errors.Is(err, http.ErrNotSupported) {
+		t.Fatalf("set write deadline on plain writer: err=%v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package nova
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ServerSentEvent 服务端推送事件
+type ServerSentEvent struct {
+	ID    string        // 事件ID，客户端重连时通过 Last-Event-ID 回传
+	Event string        // 事件名称
+	Retry time.Duration // 客户端重连间隔
+	Data  interface{}   // 事件数据，非字符串类型按 JSON 编码
+}
+
+// sseReplacer 过滤事件字段中的换行符，防止注入额外字段
+var sseReplacer = strings.NewReplacer("\n", "", "\r", "")
+
+// LastEventID 获取客户端重连时携带的最后事件ID
+func (c *Context) LastEventID() string {
+	return c.Request.Header.Get("Last-Event-ID")
+}
+
+// SSEvent 发送一条服务端推送事件并立即刷新
+func (c *Context) SSEvent(name string, data interface{}) error {
+	return c.WriteSSE(ServerSentEvent{Event: name, Data: data})
+}
+
+// WriteSSE 发送完整的服务端推送事件并立即刷新
+func (c *Context) WriteSSE(event ServerSentEvent) error {
+	c.setSSEHeaders()
+
+	var b strings.Builder
+	if event.ID != "" {
+		b.WriteString("id: " + sseReplacer.Replace(event.ID) + "\n")
+	}
+	if event.Event != "" {
+		b.WriteString("event: " + sseReplacer.Replace(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		b.WriteString(fmt.Sprintf("retry: %d\n", event.Retry.Milliseconds()))
+	}
+
+	data, err := encodeSSEData(event.Data)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	if _, err := io.WriteString(c.Writer, b.String()); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// SSEKeepAlive 发送注释行保持连接，避免被代理因空闲断开
+func (c *Context) SSEKeepAlive() error {
+	c.setSSEHeaders()
+	if _, err := io.WriteString(c.Writer, ": keepalive\n\n"); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// Stream 流式输出响应，step 返回 false 时结束，返回值表示客户端是否已断开
+// 流的时长不受 Engine 为处理函数设置的 30 秒超时限制，并会清除 http.Server.WriteTimeout 设置的写超时；
+// 但 c.Request.Context() 仍会在超时后取消，step 中的数据库查询等操作需要自行控制超时
+func (c *Context) Stream(step func(w io.Writer) bool) bool {
+	c.clearWriteDeadline()
+	done := c.clientDone()
+	for {
+		select {
+		case <-done:
+			return true
+		default:
+			keepOpen := step(c.Writer)
+			c.Writer.Flush()
+			if !keepOpen {
+				return false
+			}
+		}
+	}
+}
+
+// SSEStream 持续推送通道中的事件，并按 keepAlive 间隔发送心跳
+// 通道关闭时返回 false，客户端断开或写入失败时返回 true；时长限制与 Stream 相同
+func (c *Context) SSEStream(events <-chan ServerSentEvent, keepAlive time.Duration) bool {
+	c.clearWriteDeadline()
+	c.setSSEHeaders()
+	c.Writer.Flush()
+
+	var tick <-chan time.Time
+	if keepAlive > 0 {
+		ticker := time.NewTicker(keepAlive)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	done := c.clientDone()
+	for {
+		select {
+		case <-done:
+			return true
+		case <-tick:
+			if err := c.SSEKeepAlive(); err != nil {
+				return true
+			}
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			if err := c.WriteSSE(event); err != nil {
+				return true
+			}
+		}
+	}
+}
+
+// clientDone 返回客户端断开连接时关闭的通道，不受 Engine 为处理函数设置的超时影响
+func (c *Context) clientDone() <-chan struct{} {
+	if c.clientCtx != nil {
+		return c.clientCtx.Done()
+	}
+	return c.Request.Context().Done()
+}
+
+// clearWriteDeadline 清除连接的写超时，避免长连接被 http.Server.WriteTimeout 中断；底层不支持时忽略
+func (c *Context) clearWriteDeadline() {
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+}
+
+// setSSEHeaders 设置事件流响应头
+func (c *Context) setSSEHeaders() {
+	header := c.Writer.Header()
+	if header.Get("Content-Type") == "text/event-stream" {
+		return
+	}
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// encodeSSEData 编码事件数据
+func encodeSSEData(data interface{}) (string, error) {
+	switch v := data.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
@@ -0,0 +1,146 @@
+package nova
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试事件格式、响应头、Last-Event-ID 与心跳
+func TestWriteSSE(t *testing.T) {
+	e := NewEngine()
+	var lastID string
+	e.GET("/events", func(c *Context) {
+		lastID = c.LastEventID()
+		c.WriteSSE(ServerSentEvent{
+			ID:    "7\r\ndata: injected",
+			Event: "progress\nretry: 1",
+			Retry: 1500 * time.Millisecond,
+			Data:  "line1\nline2\r\nline3",
+		})
+		c.SSEvent("done", map[string]int{"percent": 100})
+		c.SSEKeepAlive()
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+	r.Header.Set("Last-Event-ID", "6")
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+
+	want := "id: 7data: injected\n" +
+		"event: progressretry: 1\n" +
+		"retry: 1500\n" +
+		"data: line1\ndata: line2\ndata: line3\n\n" +
+		"event: done\n" +
+		"data: {\"percent\":100}\n\n" +
+		": keepalive\n\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+	if lastID != "6" {
+		t.Errorf("LastEventID = %q, want 6", lastID)
+	}
+	if w.Code != http.StatusOK || !w.Flushed {
+		t.Errorf("code = %d, flushed = %v", w.Code, w.Flushed)
+	}
+	for k, v := range map[string]string{
+		"Content-Type":      "text/event-stream",
+		"Cache-Control":     "no-cache",
+		"Connection":        "keep-alive",
+		"X-Accel-Buffering": "no",
+	} {
+		if got := w.Header().Get(k); got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+}
+
+// 测试 Stream 与 SSEStream 的结束条件：客户端断开返回 true，正常结束返回 false，且不受处理函数超时影响
+func TestStream(t *testing.T) {
+	e := NewEngine()
+	var disconnected bool
+	var cancelClient context.CancelFunc // 非 nil 时在处理过程中模拟客户端断开
+	e.GET("/stream", func(c *Context) {
+		n := 0
+		disconnected = c.Stream(func(w io.Writer) bool {
+			n++
+			io.WriteString(w, "tick\n")
+			if cancelClient != nil && n == 2 {
+				cancelClient()
+			}
+			return n < 3
+		})
+	})
+	e.GET("/sse", func(c *Context) {
+		events := make(chan ServerSentEvent, 2)
+		events <- ServerSentEvent{Data: "a"}
+		if cancelClient != nil {
+			cancelClient()
+		} else {
+			events <- ServerSentEvent{Data: "b"}
+			close(events)
+		}
+		disconnected = c.SSEStream(events, 0)
+	})
+	e.GET("/keepalive", func(c *Context) {
+		events := make(chan ServerSentEvent)
+		go func() {
+			time.Sleep(30 * time.Millisecond)
+			close(events)
+		}()
+		disconnected = c.SSEStream(events, 5*time.Millisecond)
+	})
+	e.GET("/deadline", func(c *Context) {
+		// 模拟处理函数超时：请求 context 已取消，但客户端仍然连接
+		expired, cancel := context.WithCancel(c.Request.Context())
+		cancel()
+		c.Request = c.Request.WithContext(expired)
+		n := 0
+		disconnected = c.Stream(func(w io.Writer) bool {
+			n++
+			io.WriteString(w, "tick\n")
+			return n < 3
+		})
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		cancel     bool
+		want       bool
+		wantBody   string
+		wantPrefix bool
+	}{
+		{name: "stream ends", path: "/stream", want: false, wantBody: "tick\ntick\ntick\n"},
+		{name: "stream client gone", path: "/stream", cancel: true, want: true, wantBody: "tick\ntick\n"},
+		{name: "sse channel closed", path: "/sse", want: false, wantBody: "data: a\n\ndata: b\n\n"},
+		{name: "sse client gone", path: "/sse", cancel: true, want: true},
+		{name: "sse keepalive", path: "/keepalive", want: false, wantBody: ": keepalive\n\n", wantPrefix: true},
+		{name: "handler deadline", path: "/deadline", want: false, wantBody: "tick\ntick\ntick\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			cancelClient = nil
+			if tt.cancel {
+				cancelClient = cancel
+			}
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, r)
+
+			if disconnected != tt.want {
+				t.Errorf("disconnected = %v, want %v", disconnected, tt.want)
+			}
+			body := w.Body.String()
+			if tt.wantPrefix && !strings.HasPrefix(body, tt.wantBody) || !tt.wantPrefix && tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
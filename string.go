@@ -3,6 +3,8 @@ package nova
 import (
 	"strings"
 	"unicode"
+
+	"github.com/xzl-go/nova/validator"
 )
 
 // IsEmpty 检查字符串是否为空
@@ -65,25 +67,9 @@ func IsURL(s string) bool {
 	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
 }
 
-// IsIP 检查字符串是否为 IP 地址
+// IsIP 检查字符串是否为 IPv4 或 IPv6 地址
 func IsIP(s string) bool {
-	parts := strings.Split(s, ".")
-	if len(parts) != 4 {
-		return false
-	}
-	for _, part := range parts {
-		if !IsNumeric(part) {
-			return false
-		}
-		num := 0
-		for _, c := range part {
-			num = num*10 + int(c-'0')
-		}
-		if num < 0 || num > 255 {
-			return false
-		}
-	}
-	return true
+	return validator.IsIP(s)
 }
 
 // IsMobile 检查字符串是否为手机号
@@ -97,18 +83,9 @@ func IsMobile(s string) bool {
 	return IsNumeric(s)
 }
 
-// IsIDCard 检查字符串是否为身份证号
+// IsIDCard 检查字符串是否为身份证号，校验出生日期与 GB 11643 校验码
 func IsIDCard(s string) bool {
-	if len(s) != 18 {
-		return false
-	}
-	for i := 0; i < 17; i++ {
-		if !unicode.IsDigit(rune(s[i])) {
-			return false
-		}
-	}
-	last := s[17]
-	return unicode.IsDigit(rune(last)) || last == 'X' || last == 'x'
+	return validator.IsIDCard(s)
 }
 
 // IsPassword 检查字符串是否为强密码
@@ -0,0 +1,12 @@
+package nova
+
+import "github.com/xzl-go/nova/binding"
+
+// ValidationGroups 设置当前路由的校验分组，绑定请求时只校验未声明 groups 标签或属于这些分组的字段
+// 用于同一结构体在创建与更新等场景下执行不同的校验规则
+func ValidationGroups(groups ...string) HandlerFunc {
+	return func(c *Context) {
+		c.Request = c.Request.WithContext(binding.WithGroups(c.Request.Context(), groups...))
+		c.Next()
+	}
+}
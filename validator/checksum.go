@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+// idCardWeights GB 11643 校验码加权因子
+var idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+// idCardCheckCodes GB 11643 校验码，下标为加权和模 11 的结果
+const idCardCheckCodes = "10X98765432"
+
+// IsIDCard 校验 18 位居民身份证号码的地址码、出生日期与 GB 11643 校验码
+func IsIDCard(s string) bool {
+	if len(s) != 18 || s[0] == '0' {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		sum += int(c-'0') * idCardWeights[i]
+	}
+
+	birth, err := time.Parse("20060102", s[6:14])
+	if err != nil || birth.Year() < 1900 || birth.After(time.Now()) {
+		return false
+	}
+
+	check := s[17]
+	if check == 'x' {
+		check = 'X'
+	}
+	return idCardCheckCodes[sum%11] == check
+}
+
+// IsBankCard 校验 12 至 19 位银行卡号的 Luhn 校验位
+func IsBankCard(s string) bool {
+	if len(s) < 12 || len(s) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// creditCodeChars GB 32100 统一社会信用代码字符集，下标为字符代表的数值
+const creditCodeChars = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+
+// creditCodeWeights GB 32100 校验码加权因子
+var creditCodeWeights = [17]int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+
+// IsCreditCode 校验 18 位统一社会信用代码的字符集、行政区划码与 GB 32100 校验码
+func IsCreditCode(s string) bool {
+	if len(s) != 18 {
+		return false
+	}
+	s = strings.ToUpper(s)
+	for i := 2; i < 8; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		v := strings.IndexByte(creditCodeChars, s[i])
+		if v < 0 {
+			return false
+		}
+		sum += v * creditCodeWeights[i]
+	}
+	check := (31 - sum%31) % 31
+	return s[17] == creditCodeChars[check]
+}
+
+// IsIP 校验 IPv4 或 IPv6 地址
+func IsIP(s string) bool {
+	return net.ParseIP(s) != nil
+}
+
+// IsIPv4 校验 IPv4 地址
+func IsIPv4(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+}
+
+// IsIPv6 校验 IPv6 地址
+func IsIPv6(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && strings.Contains(s, ":")
+}
@@ -0,0 +1,51 @@
+package validator
+
+import "testing"
+
+// 测试身份证、银行卡、统一社会信用代码与 IP 地址校验
+func TestChecksumValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    func(string) bool
+		value string
+		want  bool
+	}{
+		{"idcard", IsIDCard, "11010519491231002X", true},
+		{"idcard lower x", IsIDCard, "11010519491231002x", true},
+		{"idcard check digit", IsIDCard, "110105194912310021", false},
+		{"idcard birth date", IsIDCard, "110105194902300027", false},
+		{"idcard length", IsIDCard, "1101051949123100", false},
+		{"bankcard", IsBankCard, "6222021234567890128", true},
+		{"bankcard check digit", IsBankCard, "6222021234567890127", false},
+		{"bankcard luhn", IsBankCard, "4111111111111111", true},
+		{"bankcard letters", IsBankCard, "41111111111111a1", false},
+		{"credit code", IsCreditCode, "91350100M000100Y43", true},
+		{"credit code check", IsCreditCode, "91350100M000100Y44", false},
+		{"credit code charset", IsCreditCode, "91350100M000100I43", false},
+		{"ip v4", IsIP, "192.168.1.1", true},
+		{"ip v6", IsIP, "2001:db8::1", true},
+		{"ip out of range", IsIP, "999.999.999.999", false},
+		{"ipv4 only", IsIPv4, "2001:db8::1", false},
+		{"ipv4 mapped", IsIPv4, "::ffff:192.168.1.1", false},
+		{"ipv6 only", IsIPv6, "10.0.0.1", false},
+		{"ipv6", IsIPv6, "::1", true},
+	}
+	for _, tt := range tests {
+		if got := tt.fn(tt.value); got != tt.want {
+			t.Errorf("%s(%q) = %v, want %v", tt.name, tt.value, got, tt.want)
+		}
+	}
+
+	type request struct {
+		ID   string `validate:"idcard"`
+		Card string `validate:"bankcard"`
+		Name string `validate:"chinese"`
+		Addr string `validate:"ipv6"`
+	}
+	if err := ValidateStruct(&request{ID: "11010519491231002X", Card: "4111111111111111", Name: "张三", Addr: "::1"}); err != nil {
+		t.Fatalf("valid request rejected: %v", err)
+	}
+	if err := ValidateStruct(&request{ID: "110105194912310021", Card: "4111111111111112", Name: "abc", Addr: "1.1.1.1"}); len(GetValidationErrors(err)) != 4 {
+		t.Fatalf("invalid request errors = %v", GetValidationErrors(err))
+	}
+}
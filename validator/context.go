@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FuncCtx 可访问 context 的校验函数
+type FuncCtx = validator.FuncCtx
+
+// callCacheKey 单次校验缓存在 context 中的键
+type callCacheKey struct{}
+
+// RegisterValidationCtx 注册可访问 context 的校验函数，用于查询数据库、缓存等外部依赖
+// 校验函数应遵守 ctx 的超时与取消，ctx 来自 ValidateStructCtx 或绑定时的请求 context
+func RegisterValidationCtx(tag string, fn FuncCtx) error {
+	return validate.RegisterValidationCtx(tag, fn)
+}
+
+// ValidateStructCtx 使用 context 验证结构体
+func ValidateStructCtx(ctx context.Context, obj interface{}) error {
+	return ValidateStructGroupsCtx(ctx, obj)
+}
+
+// CallCache 获取校验共享的缓存，重复的查询可复用结果；不在校验过程中时返回 nil
+// 缓存范围由 WithCallCache 决定：nova.Engine 为每个请求附加一份，同一请求内的多次校验共享；
+// 直接调用 ValidateStructCtx 等函数且 ctx 中没有缓存时，只在单次校验内共享
+func CallCache(ctx context.Context) *sync.Map {
+	m, _ := ctx.Value(callCacheKey{}).(*sync.Map)
+	return m
+}
+
+// WithCallCache 为 ctx 附加校验缓存，已存在时复用；缓存在 ctx 的生命周期内有效，
+// 期间写入数据库的数据不会反映到已缓存的 unique、exists 结果中
+func WithCallCache(ctx context.Context) context.Context {
+	if CallCache(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, callCacheKey{}, &sync.Map{})
+}
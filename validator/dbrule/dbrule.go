@@ -0,0 +1,295 @@
+// Package dbrule 提供查询数据库的 unique 与 exists 校验规则
+package dbrule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	playground "github.com/go-playground/validator/v10"
+	"github.com/xzl-go/nova/cache"
+	"github.com/xzl-go/nova/database"
+	"github.com/xzl-go/nova/validator"
+)
+
+// DefaultTimeout 单次查询默认超时时间
+const DefaultTimeout = 3 * time.Second
+
+// identifierPattern 表名与列名允许的格式
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Config 数据库校验规则配置
+type Config struct {
+	DB       database.Database                    // 查询使用的数据库
+	Cache    cache.Cache                          // 可选，缓存已存在的值以减少数据库查询
+	CacheTTL time.Duration                        // 缓存有效期，默认 1 分钟
+	Timeout  time.Duration                        // 单次查询超时时间，默认 DefaultTimeout，同时受请求 context 约束
+	OnError  func(ctx context.Context, err error) // 查询失败时调用，查询失败的字段视为校验不通过
+}
+
+// Register 注册 unique 与 exists 校验规则
+// unique=users.email 要求值在 users 表的 email 列中不存在，exists=roles.id 要求值存在；
+// 切片字段的所有元素在一条查询中判断。未指定表名的 unique 保持校验切片元素互不重复的原有语义
+func Register(cfg Config) error {
+	if cfg.DB == nil {
+		return errors.New("dbrule: database is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = time.Minute
+	}
+	r := &rules{cfg: cfg}
+
+	// 带表名的 unique 使用 db_unique 消息，与切片元素互不重复的消息区分
+	validator.RegisterMessageKey("unique", func(param string) string {
+		if strings.Contains(param, ".") {
+			return "db_unique"
+		}
+		return ""
+	})
+	if err := validator.RegisterValidationCtx("unique", func(ctx context.Context, fl playground.FieldLevel) bool {
+		if !strings.Contains(fl.Param(), ".") {
+			return isUnique(fl.Field(), fl.Param())
+		}
+		found, ok := r.lookup(ctx, fl.Param(), fl.Field())
+		return ok && found == 0
+	}); err != nil {
+		return err
+	}
+	return validator.RegisterValidationCtx("exists", func(ctx context.Context, fl playground.FieldLevel) bool {
+		found, ok := r.lookup(ctx, fl.Param(), fl.Field())
+		return ok && found == len(values(fl.Field()))
+	})
+}
+
+// rules 数据库校验规则
+type rules struct {
+	cfg Config
+}
+
+// lookup 查询值在表中已存在的个数，ok 为 false 表示参数错误或查询失败
+func (r *rules) lookup(ctx context.Context, param string, field reflect.Value) (int, bool) {
+	table, column, err := parseParam(param)
+	if err != nil {
+		r.fail(ctx, err)
+		return 0, false
+	}
+	vals := values(field)
+	if len(vals) == 0 {
+		return 0, true
+	}
+
+	found := 0
+	pending := make([]interface{}, 0, len(vals))
+	memo := validator.CallCache(ctx)
+	for _, v := range vals {
+		key := cacheKey(table, column, v)
+		if memo != nil {
+			if exists, ok := memo.Load(key); ok {
+				if exists.(bool) {
+					found++
+				}
+				continue
+			}
+		}
+		if r.cfg.Cache != nil {
+			if exists, err := r.cfg.Cache.Exists(ctx, key); err == nil && exists {
+				found++
+				if memo != nil {
+					memo.Store(key, true)
+				}
+				continue
+			}
+		}
+		pending = append(pending, v)
+	}
+	if len(pending) == 0 {
+		return found, true
+	}
+
+	existing, err := r.query(ctx, table, column, pending)
+	if err != nil {
+		r.fail(ctx, err)
+		return 0, false
+	}
+	for i, v := range pending {
+		key := cacheKey(table, column, v)
+		if existing[i] {
+			found++
+			if r.cfg.Cache != nil {
+				r.cfg.Cache.Set(ctx, key, true, r.cfg.CacheTTL)
+			}
+		}
+		if memo != nil {
+			memo.Store(key, existing[i])
+		}
+	}
+	return found, true
+}
+
+// maxValuesPerQuery 单次查询最多判断的值个数，避免超出数据库的占位符上限
+const maxValuesPerQuery = 200
+
+// query 判断每个值在表中是否存在，结果与 vals 一一对应
+// 比较由数据库按列的排序规则完成，大小写不敏感的排序规则下 A@x.com 与 a@x.com 视为相同；
+// 每批值使用一条 SELECT EXISTS(...), EXISTS(...) 语句查询
+func (r *rules) query(ctx context.Context, table, column string, vals []interface{}) ([]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+
+	db := r.cfg.DB.DB()
+	if db == nil {
+		return nil, errors.New("dbrule: database is not connected")
+	}
+	db = db.WithContext(ctx)
+	exists := fmt.Sprintf("EXISTS(SELECT 1 FROM %s WHERE %s = ?)", db.Statement.Quote(table), db.Statement.Quote(column))
+
+	result := make([]bool, 0, len(vals))
+	for start := 0; start < len(vals); start += maxValuesPerQuery {
+		batch := vals[start:min(start+maxValuesPerQuery, len(vals))]
+		selects := make([]string, len(batch))
+		for i := range selects {
+			selects[i] = exists
+		}
+		row := db.Raw("SELECT "+strings.Join(selects, ", "), batch...).Row()
+		if err := row.Err(); err != nil {
+			return nil, err
+		}
+		cols := make([]interface{}, len(batch))
+		dest := make([]interface{}, len(batch))
+		for i := range cols {
+			dest[i] = &cols[i]
+		}
+		if err := row.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for _, col := range cols {
+			result = append(result, truthy(col))
+		}
+	}
+	return result, nil
+}
+
+// truthy 将 EXISTS 的结果转换为布尔值，不同数据库分别返回 bool、整数或文本
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case []byte:
+		return truthyText(string(v))
+	case string:
+		return truthyText(v)
+	default:
+		return false
+	}
+}
+
+// truthyText 文本形式的布尔值
+func truthyText(s string) bool {
+	return s == "1" || strings.EqualFold(s, "t") || strings.EqualFold(s, "true")
+}
+
+// fail 报告查询错误
+func (r *rules) fail(ctx context.Context, err error) {
+	if r.cfg.OnError != nil {
+		r.cfg.OnError(ctx, err)
+	}
+}
+
+// parseParam 解析 table.column 形式的规则参数
+func parseParam(param string) (string, string, error) {
+	table, column, ok := strings.Cut(param, ".")
+	if !ok || !identifierPattern.MatchString(table) || !identifierPattern.MatchString(column) {
+		return "", "", fmt.Errorf("dbrule: invalid param %q, want table.column", param)
+	}
+	return table, column, nil
+}
+
+// cacheKey 生成值存在性的缓存键
+func cacheKey(table, column string, v interface{}) string {
+	return fmt.Sprintf("validator:exists:%s.%s:%v", table, column, v)
+}
+
+// values 获取待查询的值，切片与数组展开为元素，零值不参与查询
+func values(field reflect.Value) []interface{} {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		seen := make(map[interface{}]struct{}, field.Len())
+		vals := make([]interface{}, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			v := values(field.Index(i))
+			if len(v) == 0 {
+				continue
+			}
+			if _, dup := seen[v[0]]; dup {
+				continue
+			}
+			seen[v[0]] = struct{}{}
+			vals = append(vals, v[0])
+		}
+		return vals
+	default:
+		if !field.IsValid() || field.IsZero() || !field.Type().Comparable() {
+			return nil
+		}
+		return []interface{}{field.Interface()}
+	}
+}
+
+// isUnique 校验切片、数组或 map 中的元素互不重复，param 为结构体元素中参与比较的字段名
+func isUnique(field reflect.Value, param string) bool {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return true
+		}
+		field = field.Elem()
+	}
+	seen := make(map[interface{}]struct{})
+	add := func(v reflect.Value) bool {
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+		if param != "" && v.Kind() == reflect.Struct {
+			v = v.FieldByName(param)
+		}
+		if !v.IsValid() || !v.Type().Comparable() {
+			return true
+		}
+		key := v.Interface()
+		if _, dup := seen[key]; dup {
+			return false
+		}
+		seen[key] = struct{}{}
+		return true
+	}
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < field.Len(); i++ {
+			if !add(field.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Map:
+		iter := field.MapRange()
+		for iter.Next() {
+			if !add(iter.Value()) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package dbrule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xzl-go/nova/i18n"
+	"github.com/xzl-go/nova/validator"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type memoryDB struct {
+	db *gorm.DB
+}
+
+func (m *memoryDB) Connect() error { return nil }
+func (m *memoryDB) Close() error   { return nil }
+func (m *memoryDB) DB() *gorm.DB   { return m.db }
+
+type signup struct {
+	Email   string   `validate:"unique=users.email"`
+	RoleIDs []int    `validate:"exists=roles.id"`
+	Tags    []string `validate:"unique"`
+}
+
+// 测试查询数据库的 unique 与 exists 规则
+func TestRules(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:dbrule?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Exec("CREATE TABLE users (email TEXT COLLATE NOCASE)")
+	db.Exec("CREATE TABLE roles (id INTEGER)")
+	db.Exec("INSERT INTO users (email) VALUES ('taken@example.com')")
+	db.Exec("INSERT INTO roles (id) VALUES (1), (2)")
+
+	var queries int
+	db.Callback().Row().Before("gorm:row").Register("count", func(*gorm.DB) { queries++ })
+
+	if err := Register(Config{DB: &memoryDB{db: db}, OnError: func(_ context.Context, err error) { t.Log(err) }}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		req  signup
+		want []string
+	}{
+		{signup{Email: "new@example.com", RoleIDs: []int{1, 2, 2}, Tags: []string{"a", "b"}}, nil},
+		{signup{Email: "taken@example.com", RoleIDs: []int{1, 3}, Tags: []string{"a", "a"}}, []string{"Email", "RoleIDs", "Tags"}},
+		{signup{Email: "Taken@Example.com", RoleIDs: []int{2}}, []string{"Email"}},
+	}
+	for _, tt := range tests {
+		queries = 0
+		err := validator.ValidateStructCtx(context.Background(), &tt.req)
+		fields := validator.GetValidationErrors(err)
+		if len(fields) != len(tt.want) {
+			t.Fatalf("%+v: errors = %v, want %v", tt.req, fields, tt.want)
+		}
+		for _, f := range tt.want {
+			if _, ok := fields[f]; !ok {
+				t.Fatalf("%+v: missing error for %s in %v", tt.req, f, fields)
+			}
+		}
+		if queries != 2 {
+			t.Fatalf("queries = %d, want 2 (one per rule)", queries)
+		}
+	}
+
+	if got := validator.LocalizedMessage(i18n.LanguageEnUS, "Email", "unique", "users.email"); got != "Email is already taken" {
+		t.Errorf("db unique message = %q", got)
+	}
+	if got := validator.LocalizedMessage(i18n.LanguageEnUS, "Tags", "unique", ""); got != "Tags must not contain duplicates" {
+		t.Errorf("unique message = %q", got)
+	}
+
+	// 同一请求内的多次校验共享查询结果
+	queries = 0
+	shared := validator.WithCallCache(context.Background())
+	for i := 0; i < 2; i++ {
+		if err := validator.ValidateStructCtx(shared, &signup{Email: "new@example.com", RoleIDs: []int{1}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if queries != 2 {
+		t.Fatalf("queries with shared cache = %d, want 2", queries)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := validator.ValidateStructCtx(ctx, &signup{Email: "new@example.com"}); err == nil {
+		t.Fatal("canceled context should fail the database rule")
+	}
+}
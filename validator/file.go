@@ -0,0 +1,147 @@
+package validator
+
+import (
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// sniffLen 检测文件类型时读取的字节数，与 http.DetectContentType 一致
+const sniffLen = 512
+
+// 注册文件上传验证器
+// file_max=5MB 限制单个文件大小，单位支持 B、KB、MB、GB；
+// mime=image/png image/jpeg 按文件内容检测类型，多个类型以空格或 0x7C 分隔（mime=image/png0x7Cimage/jpeg），支持 image/* 通配；
+// 标签中不能直接使用 |，validator 会将其解析为规则之间的“或”，见 explainTagPanic；
+// max_files=3 限制文件数量。file_max 与 mime 同时适用于 *multipart.FileHeader 与 []*multipart.FileHeader 字段
+func registerFileValidators() {
+	validate.RegisterValidation("file_max", func(fl validator.FieldLevel) bool {
+		limit, err := ParseSize(fl.Param())
+		if err != nil {
+			panic("validator: invalid file_max param " + fl.Param())
+		}
+		for _, fh := range fileHeaders(fl.Field()) {
+			if fh.Size > limit {
+				return false
+			}
+		}
+		return true
+	})
+
+	validate.RegisterValidation("mime", func(fl validator.FieldLevel) bool {
+		// validator 解析标签时已将 0x7C 还原为 |
+		allowed := strings.FieldsFunc(fl.Param(), func(r rune) bool { return r == ' ' || r == '|' })
+		for _, fh := range fileHeaders(fl.Field()) {
+			if !matchMIME(detectFileType(fh), allowed) {
+				return false
+			}
+		}
+		return true
+	})
+
+	validate.RegisterValidation("max_files", func(fl validator.FieldLevel) bool {
+		n, err := strconv.Atoi(fl.Param())
+		if err != nil {
+			panic("validator: invalid max_files param " + fl.Param())
+		}
+		return len(fileHeaders(fl.Field())) <= n
+	})
+}
+
+// explainTagPanic 为 mime=image/png|image/jpeg 写法导致的 panic 补充说明
+// validator 将 | 解析为规则之间的“或”，image/jpeg 被当作未定义的规则，原始信息难以定位问题
+func explainTagPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if msg, ok := r.(string); ok && strings.HasPrefix(msg, "Undefined validation function '") {
+		name := strings.TrimPrefix(msg, "Undefined validation function '")
+		if i := strings.IndexByte(name, '\''); i >= 0 && strings.Contains(name[:i], "/") {
+			panic("validator: " + msg + `; "|" separates rules, list mime types with spaces or 0x7C, e.g. mime=image/png image/jpeg`)
+		}
+	}
+	panic(r)
+}
+
+// ParseSize 解析 5MB、512KB、1024 形式的文件大小，单位按 1024 进制
+func ParseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+	} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * multiplier, nil
+}
+
+// fileHeaders 获取字段中的上传文件
+// 指针字段由 validator 解引用后传入，需取回原指针
+func fileHeaders(field reflect.Value) []*multipart.FileHeader {
+	switch v := field.Interface().(type) {
+	case *multipart.FileHeader:
+		if v != nil {
+			return []*multipart.FileHeader{v}
+		}
+	case []*multipart.FileHeader:
+		return v
+	case multipart.FileHeader:
+		if field.CanAddr() {
+			return []*multipart.FileHeader{field.Addr().Interface().(*multipart.FileHeader)}
+		}
+		return []*multipart.FileHeader{&v}
+	}
+	return nil
+}
+
+// detectFileType 按文件内容检测 MIME 类型
+func detectFileType(fh *multipart.FileHeader) string {
+	if fh == nil {
+		return ""
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	buf := make([]byte, sniffLen)
+	n, _ := f.Read(buf)
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// matchMIME 检查类型是否在允许列表中，支持 image/* 形式的通配
+func matchMIME(mediaType string, allowed []string) bool {
+	if mediaType == "" {
+		return false
+	}
+	for _, a := range allowed {
+		a = strings.ToLower(strings.TrimSpace(a))
+		if a == mediaType || (strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*"))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// ValidateStructGroups 按场景分组校验结构体
+// 声明了 groups 标签（如 groups:"create,update"）的字段只在任一分组激活时校验，未声明的字段始终校验；
+// 未指定分组时校验全部字段。结构体级校验不受分组影响
+func ValidateStructGroups(obj interface{}, groups ...string) error {
+	return ValidateStructGroupsCtx(context.Background(), obj, groups...)
+}
+
+// ValidateStructGroupsCtx 按场景分组校验结构体，ctx 传递给 RegisterValidationCtx 注册的校验函数
+func ValidateStructGroupsCtx(ctx context.Context, obj interface{}, groups ...string) error {
+	defer explainTagPanic()
+	ctx = WithCallCache(ctx)
+	if len(groups) == 0 {
+		return validate.StructCtx(ctx, obj)
+	}
+	typ := reflect.TypeOf(obj)
+	return validate.StructFilteredCtx(ctx, obj, func(ns []byte) bool {
+		field, ok := fieldByNamespace(typ, string(ns))
+		if !ok {
+			return false
+		}
+		declared := field.Tag.Get("groups")
+		if declared == "" {
+			return false
+		}
+		for _, g := range strings.Split(declared, ",") {
+			for _, active := range groups {
+				if strings.TrimSpace(g) == active {
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+// fieldByNamespace 按结构体命名空间（如 Req.Items[0].ID）查找字段
+func fieldByNamespace(typ reflect.Type, namespace string) (reflect.StructField, bool) {
+	var field reflect.StructField
+	segments := strings.Split(namespace, ".")
+	if len(segments) < 2 {
+		return field, false
+	}
+	for _, seg := range segments[1:] {
+		name := seg
+		if i := strings.IndexByte(seg, '['); i >= 0 {
+			name = seg[:i]
+		}
+		typ = derefType(typ)
+		if typ.Kind() != reflect.Struct {
+			return field, false
+		}
+		f, ok := typ.FieldByName(name)
+		if !ok {
+			return field, false
+		}
+		field = f
+		typ = f.Type
+		for n := strings.Count(seg, "["); n > 0; n-- {
+			typ = derefType(typ)
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				typ = typ.Elem()
+			}
+		}
+	}
+	return field, true
+}
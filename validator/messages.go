@@ -0,0 +1,317 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xzl-go/nova/i18n"
+)
+
+const (
+	// defaultMessageKey 未定义消息的规则使用的消息键
+	defaultMessageKey = "default"
+	// labelKeyPrefix 字段显示名在翻译目录中的键前缀，与规则消息分开
+	labelKeyPrefix = "label."
+)
+
+var (
+	translatorMu sync.RWMutex
+	translator   = newTranslator()
+
+	// messageKeyFuncs 按规则参数选择消息键的函数，键为规则标签
+	messageKeyFuncs sync.Map
+)
+
+// 内置校验消息，键为规则标签，%[1]s 为字段名，%[2]s 为规则参数；label. 前缀的键为字段显示名
+var builtinMessages = map[i18n.Language]map[string]string{
+	i18n.LanguageZhCN: {
+		defaultMessageKey:      "%[1]s 验证失败",
+		"label.body":           "请求体",
+		"required":             "%[1]s 不能为空",
+		"type":                 "%[1]s 类型不正确",
+		"unknown":              "%[1]s 是未定义的字段",
+		"trailing":             "%[1]s 包含多余的数据",
+		"max_depth":            "%[1]s 嵌套层级不能超过 %[2]s",
+		"max_array_length":     "%[1]s 数组长度不能超过 %[2]s",
+		"email":                "%[1]s 必须是有效的邮箱地址",
+		"url":                  "%[1]s 必须是有效的URL地址",
+		"min":                  "%[1]s 不能小于 %[2]s",
+		"max":                  "%[1]s 不能大于 %[2]s",
+		"len":                  "%[1]s 长度必须为 %[2]s",
+		"oneof":                "%[1]s 必须是以下值之一: %[2]s",
+		"unique":               "%[1]s 不能重复",
+		"exists":               "%[1]s 不存在",
+		"db_unique":            "%[1]s 已存在",
+		"alpha":                "%[1]s 只能包含字母",
+		"numeric":              "%[1]s 只能包含数字",
+		"alphanumeric":         "%[1]s 只能包含字母和数字",
+		"datetime":             "%[1]s 必须是有效的日期时间格式",
+		"mobile":               "%[1]s 必须是有效的手机号",
+		"idcard":               "%[1]s 必须是有效的身份证号",
+		"password":             "%[1]s 必须包含大小写字母、数字和特殊字符，且长度不少于8位",
+		"chinese":              "%[1]s 只能包含中文字符",
+		"english":              "%[1]s 只能包含英文字符",
+		"date":                 "%[1]s 必须是有效的日期格式",
+		"ip":                   "%[1]s 必须是有效的IP地址",
+		"postcode":             "%[1]s 必须是有效的邮政编码",
+		"chinese_name":         "%[1]s 必须是有效的中文姓名",
+		"english_name":         "%[1]s 必须是有效的英文姓名",
+		"bankcard":             "%[1]s 必须是有效的银行卡号",
+		"credit_code":          "%[1]s 必须是有效的社会信用代码",
+		"file_max":             "%[1]s 文件大小不能超过 %[2]s",
+		"mime":                 "%[1]s 文件类型必须是 %[2]s",
+		"max_files":            "%[1]s 文件数量不能超过 %[2]s",
+		"eqfield":              "%[1]s 必须与 %[2]s 相同",
+		"nefield":              "%[1]s 不能与 %[2]s 相同",
+		"gtfield":              "%[1]s 必须大于 %[2]s",
+		"gtefield":             "%[1]s 必须大于或等于 %[2]s",
+		"ltfield":              "%[1]s 必须小于 %[2]s",
+		"ltefield":             "%[1]s 必须小于或等于 %[2]s",
+		"required_if":          "%[1]s 在 %[2]s 时不能为空",
+		"required_unless":      "%[1]s 除 %[2]s 外不能为空",
+		"required_with":        "%[1]s 在 %[2]s 存在时不能为空",
+		"required_with_all":    "%[1]s 在 %[2]s 均存在时不能为空",
+		"required_without":     "%[1]s 在 %[2]s 不存在时不能为空",
+		"required_without_all": "%[1]s 在 %[2]s 均不存在时不能为空",
+		"excluded_if":          "%[1]s 在 %[2]s 时必须为空",
+		"excluded_with":        "%[1]s 在 %[2]s 存在时必须为空",
+	},
+	i18n.LanguageEnUS: {
+		defaultMessageKey:      "%[1]s is invalid",
+		"label.body":           "request body",
+		"required":             "%[1]s is required",
+		"type":                 "%[1]s has an invalid type",
+		"unknown":              "%[1]s is not an allowed field",
+		"trailing":             "%[1]s contains unexpected trailing data",
+		"max_depth":            "%[1]s must not be nested deeper than %[2]s levels",
+		"max_array_length":     "%[1]s must not contain more than %[2]s items",
+		"email":                "%[1]s must be a valid email address",
+		"url":                  "%[1]s must be a valid URL",
+		"min":                  "%[1]s must be at least %[2]s",
+		"max":                  "%[1]s must be at most %[2]s",
+		"len":                  "%[1]s must have a length of %[2]s",
+		"oneof":                "%[1]s must be one of: %[2]s",
+		"unique":               "%[1]s must not contain duplicates",
+		"exists":               "%[1]s does not exist",
+		"db_unique":            "%[1]s is already taken",
+		"alpha":                "%[1]s may only contain letters",
+		"numeric":              "%[1]s may only contain digits",
+		"alphanumeric":         "%[1]s may only contain letters and digits",
+		"datetime":             "%[1]s must be a valid date and time",
+		"mobile":               "%[1]s must be a valid mobile number",
+		"idcard":               "%[1]s must be a valid ID card number",
+		"password":             "%[1]s must be at least 8 characters and contain upper and lower case letters, digits and symbols",
+		"chinese":              "%[1]s may only contain Chinese characters",
+		"english":              "%[1]s may only contain English letters",
+		"date":                 "%[1]s must be a valid date",
+		"ip":                   "%[1]s must be a valid IP address",
+		"postcode":             "%[1]s must be a valid postal code",
+		"chinese_name":         "%[1]s must be a valid Chinese name",
+		"english_name":         "%[1]s must be a valid English name",
+		"bankcard":             "%[1]s must be a valid bank card number",
+		"credit_code":          "%[1]s must be a valid unified social credit code",
+		"file_max":             "%[1]s must not be larger than %[2]s",
+		"mime":                 "%[1]s must be a file of type %[2]s",
+		"max_files":            "%[1]s must not contain more than %[2]s files",
+		"eqfield":              "%[1]s must match %[2]s",
+		"nefield":              "%[1]s must not match %[2]s",
+		"gtfield":              "%[1]s must be greater than %[2]s",
+		"gtefield":             "%[1]s must be greater than or equal to %[2]s",
+		"ltfield":              "%[1]s must be less than %[2]s",
+		"ltefield":             "%[1]s must be less than or equal to %[2]s",
+		"required_if":          "%[1]s is required when %[2]s",
+		"required_unless":      "%[1]s is required unless %[2]s",
+		"required_with":        "%[1]s is required when %[2]s is present",
+		"required_with_all":    "%[1]s is required when %[2]s are all present",
+		"required_without":     "%[1]s is required when %[2]s is missing",
+		"required_without_all": "%[1]s is required when %[2]s are all missing",
+		"excluded_if":          "%[1]s must be empty when %[2]s",
+		"excluded_with":        "%[1]s must be empty when %[2]s is present",
+	},
+	i18n.LanguageJaJP: {
+		defaultMessageKey:      "%[1]s が正しくありません",
+		"label.body":           "リクエストボディ",
+		"required":             "%[1]s は必須です",
+		"type":                 "%[1]s の型が正しくありません",
+		"unknown":              "%[1]s は定義されていないフィールドです",
+		"trailing":             "%[1]s に余分なデータがあります",
+		"max_depth":            "%[1]s のネストは %[2]s 階層以下にしてください",
+		"max_array_length":     "%[1]s の要素数は %[2]s 以下にしてください",
+		"email":                "%[1]s は有効なメールアドレスである必要があります",
+		"url":                  "%[1]s は有効なURLである必要があります",
+		"min":                  "%[1]s は %[2]s 以上である必要があります",
+		"max":                  "%[1]s は %[2]s 以下である必要があります",
+		"len":                  "%[1]s の長さは %[2]s である必要があります",
+		"oneof":                "%[1]s は次のいずれかである必要があります: %[2]s",
+		"unique":               "%[1]s に重複があってはいけません",
+		"exists":               "%[1]s は存在しません",
+		"db_unique":            "%[1]s は既に使用されています",
+		"alpha":                "%[1]s には英字のみ使用できます",
+		"numeric":              "%[1]s には数字のみ使用できます",
+		"alphanumeric":         "%[1]s には英数字のみ使用できます",
+		"datetime":             "%[1]s は有効な日時である必要があります",
+		"mobile":               "%[1]s は有効な携帯電話番号である必要があります",
+		"idcard":               "%[1]s は有効な身分証番号である必要があります",
+		"password":             "%[1]s は8文字以上で、大文字・小文字・数字・記号を含む必要があります",
+		"chinese":              "%[1]s には中国語の文字のみ使用できます",
+		"english":              "%[1]s には英字のみ使用できます",
+		"date":                 "%[1]s は有効な日付である必要があります",
+		"ip":                   "%[1]s は有効なIPアドレスである必要があります",
+		"postcode":             "%[1]s は有効な郵便番号である必要があります",
+		"chinese_name":         "%[1]s は有効な中国語の氏名である必要があります",
+		"english_name":         "%[1]s は有効な英語の氏名である必要があります",
+		"bankcard":             "%[1]s は有効な銀行カード番号である必要があります",
+		"credit_code":          "%[1]s は有効な統一社会信用コードである必要があります",
+		"file_max":             "%[1]s のファイルサイズは %[2]s 以下にしてください",
+		"mime":                 "%[1]s のファイル形式は %[2]s である必要があります",
+		"max_files":            "%[1]s のファイル数は %[2]s 以下にしてください",
+		"eqfield":              "%[1]s は %[2]s と一致する必要があります",
+		"nefield":              "%[1]s は %[2]s と異なる必要があります",
+		"gtfield":              "%[1]s は %[2]s より大きい必要があります",
+		"gtefield":             "%[1]s は %[2]s 以上である必要があります",
+		"ltfield":              "%[1]s は %[2]s より小さい必要があります",
+		"ltefield":             "%[1]s は %[2]s 以下である必要があります",
+		"required_if":          "%[2]s の場合、%[1]s は必須です",
+		"required_unless":      "%[2]s でない限り、%[1]s は必須です",
+		"required_with":        "%[2]s がある場合、%[1]s は必須です",
+		"required_with_all":    "%[2]s がすべてある場合、%[1]s は必須です",
+		"required_without":     "%[2]s がない場合、%[1]s は必須です",
+		"required_without_all": "%[2]s がすべてない場合、%[1]s は必須です",
+		"excluded_if":          "%[2]s の場合、%[1]s は空である必要があります",
+		"excluded_with":        "%[2]s がある場合、%[1]s は空である必要があります",
+	},
+	i18n.LanguageKoKR: {
+		defaultMessageKey:      "%[1]s 값이 올바르지 않습니다",
+		"label.body":           "요청 본문",
+		"required":             "%[1]s 은(는) 필수입니다",
+		"type":                 "%[1]s 의 형식이 올바르지 않습니다",
+		"unknown":              "%[1]s 은(는) 정의되지 않은 필드입니다",
+		"trailing":             "%[1]s 에 불필요한 데이터가 있습니다",
+		"max_depth":            "%[1]s 의 중첩 단계는 %[2]s 이하여야 합니다",
+		"max_array_length":     "%[1]s 의 항목 수는 %[2]s 개 이하여야 합니다",
+		"email":                "%[1]s 은(는) 올바른 이메일 주소여야 합니다",
+		"url":                  "%[1]s 은(는) 올바른 URL이어야 합니다",
+		"min":                  "%[1]s 은(는) %[2]s 이상이어야 합니다",
+		"max":                  "%[1]s 은(는) %[2]s 이하여야 합니다",
+		"len":                  "%[1]s 의 길이는 %[2]s 이어야 합니다",
+		"oneof":                "%[1]s 은(는) 다음 중 하나여야 합니다: %[2]s",
+		"unique":               "%[1]s 에 중복 값이 있으면 안 됩니다",
+		"exists":               "%[1]s 이(가) 존재하지 않습니다",
+		"db_unique":            "%[1]s 은(는) 이미 사용 중입니다",
+		"alpha":                "%[1]s 에는 영문자만 사용할 수 있습니다",
+		"numeric":              "%[1]s 에는 숫자만 사용할 수 있습니다",
+		"alphanumeric":         "%[1]s 에는 영문자와 숫자만 사용할 수 있습니다",
+		"datetime":             "%[1]s 은(는) 올바른 날짜와 시간이어야 합니다",
+		"mobile":               "%[1]s 은(는) 올바른 휴대폰 번호여야 합니다",
+		"idcard":               "%[1]s 은(는) 올바른 신분증 번호여야 합니다",
+		"password":             "%[1]s 은(는) 8자 이상이며 대문자, 소문자, 숫자, 특수문자를 포함해야 합니다",
+		"chinese":              "%[1]s 에는 중국어 문자만 사용할 수 있습니다",
+		"english":              "%[1]s 에는 영문자만 사용할 수 있습니다",
+		"date":                 "%[1]s 은(는) 올바른 날짜여야 합니다",
+		"ip":                   "%[1]s 은(는) 올바른 IP 주소여야 합니다",
+		"postcode":             "%[1]s 은(는) 올바른 우편번호여야 합니다",
+		"chinese_name":         "%[1]s 은(는) 올바른 중국어 이름이어야 합니다",
+		"english_name":         "%[1]s 은(는) 올바른 영어 이름이어야 합니다",
+		"bankcard":             "%[1]s 은(는) 올바른 은행 카드 번호여야 합니다",
+		"credit_code":          "%[1]s 은(는) 올바른 통일 사회 신용 코드여야 합니다",
+		"file_max":             "%[1]s 의 파일 크기는 %[2]s 이하여야 합니다",
+		"mime":                 "%[1]s 의 파일 형식은 %[2]s 이어야 합니다",
+		"max_files":            "%[1]s 의 파일 수는 %[2]s 개 이하여야 합니다",
+		"eqfield":              "%[1]s 은(는) %[2]s 와(과) 같아야 합니다",
+		"nefield":              "%[1]s 은(는) %[2]s 와(과) 달라야 합니다",
+		"gtfield":              "%[1]s 은(는) %[2]s 보다 커야 합니다",
+		"gtefield":             "%[1]s 은(는) %[2]s 이상이어야 합니다",
+		"ltfield":              "%[1]s 은(는) %[2]s 보다 작아야 합니다",
+		"ltefield":             "%[1]s 은(는) %[2]s 이하여야 합니다",
+		"required_if":          "%[2]s 인 경우 %[1]s 은(는) 필수입니다",
+		"required_unless":      "%[2]s 이 아닌 경우 %[1]s 은(는) 필수입니다",
+		"required_with":        "%[2]s 이(가) 있으면 %[1]s 은(는) 필수입니다",
+		"required_with_all":    "%[2]s 이(가) 모두 있으면 %[1]s 은(는) 필수입니다",
+		"required_without":     "%[2]s 이(가) 없으면 %[1]s 은(는) 필수입니다",
+		"required_without_all": "%[2]s 이(가) 모두 없으면 %[1]s 은(는) 필수입니다",
+		"excluded_if":          "%[2]s 인 경우 %[1]s 은(는) 비어 있어야 합니다",
+		"excluded_with":        "%[2]s 이(가) 있으면 %[1]s 은(는) 비어 있어야 합니다",
+	},
+}
+
+// newTranslator 创建加载内置消息的翻译器，默认语言为简体中文
+func newTranslator() *i18n.I18n {
+	t := i18n.New(i18n.LanguageZhCN)
+	for lang, messages := range builtinMessages {
+		copied := make(map[string]string, len(messages))
+		for k, v := range messages {
+			copied[k] = v
+		}
+		t.LoadMessages(lang, copied)
+	}
+	return t
+}
+
+// Translator 获取校验消息使用的翻译器
+func Translator() *i18n.I18n {
+	translatorMu.RLock()
+	defer translatorMu.RUnlock()
+	return translator
+}
+
+// SetTranslator 替换校验消息使用的翻译器
+// 消息以规则标签为键，%[1]s 为字段名，%[2]s 为规则参数；缺失的规则使用 default 键，字段显示名使用 label.<名称> 键
+func SetTranslator(t *i18n.I18n) {
+	translatorMu.Lock()
+	defer translatorMu.Unlock()
+	translator = t
+}
+
+// RegisterMessage 注册或覆盖指定语言下规则的校验消息
+func RegisterMessage(lang i18n.Language, tag, message string) {
+	Translator().SetMessage(lang, tag, message)
+}
+
+// RegisterMessageKey 为规则注册按参数选择消息键的函数，用于同一标签在不同参数下含义不同的规则，
+// 如 unique=users.email 使用 db_unique 消息，而 unique 保持元素互不重复的消息
+func RegisterMessageKey(tag string, fn func(param string) string) {
+	messageKeyFuncs.Store(tag, fn)
+}
+
+// messageKey 获取规则对应的消息键
+func messageKey(tag, param string) string {
+	if fn, ok := messageKeyFuncs.Load(tag); ok {
+		if key := fn.(func(string) string)(param); key != "" {
+			return key
+		}
+	}
+	return tag
+}
+
+// LocalizedMessage 获取指定语言下字段未通过规则时的错误信息
+func LocalizedMessage(lang i18n.Language, field, tag, param string) string {
+	t := Translator()
+	key := messageKey(tag, param)
+	format := t.Translate(lang, key)
+	if format == key && key != tag {
+		format = t.Translate(lang, tag)
+		key = tag
+	}
+	if format == key {
+		format = t.Translate(lang, defaultMessageKey)
+	}
+	if !strings.Contains(format, "%") {
+		return format
+	}
+	return fmt.Sprintf(format, field, param)
+}
+
+// RegisterLabel 注册或覆盖指定语言下字段显示名的翻译，label 为 label 标签的值
+func RegisterLabel(lang i18n.Language, label, text string) {
+	Translator().SetMessage(lang, labelKeyPrefix+label, text)
+}
+
+// TranslateLabel 翻译字段显示名，只查找 label. 前缀的键，目录中不存在时原样返回
+func TranslateLabel(lang i18n.Language, label string) string {
+	key := labelKeyPrefix + label
+	if text := Translator().Translate(lang, key); text != key {
+		return text
+	}
+	return label
+}
@@ -0,0 +1,409 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// JSONSchemaDialect 导出的 JSON Schema 版本
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema JSON Schema 2020-12 文档
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// formats 映射为 JSON Schema format 的规则
+var formats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"date":     "date",
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// RegisterPattern 注册基于正则表达式的校验规则，导出 JSON Schema 时映射为 pattern
+// 与 RegisterValidation 相同，应在开始校验前完成注册；JSONSchema 可与之并发调用
+func RegisterPattern(tag, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	if err := validate.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+		return re.MatchString(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+	patternsMu.Lock()
+	patterns[tag] = re
+	patternsMu.Unlock()
+	return nil
+}
+
+// lookupPattern 获取校验标签对应的正则表达式
+func lookupPattern(tag string) (*regexp.Regexp, bool) {
+	patternsMu.RLock()
+	defer patternsMu.RUnlock()
+	re, ok := patterns[tag]
+	return re, ok
+}
+
+// JSONSchema 将结构体的 json 与 validate 标签导出为 JSON Schema 2020-12
+// 支持 required、min、max、len、gt、gte、lt、lte、eq、oneof、unique、dive、格式规则与正则规则，
+// 嵌套结构体输出到 $defs 并以 $ref 引用，label 标签输出为 title，default 标签输出为 default
+func JSONSchema(obj interface{}) *Schema {
+	g := &schemaGenerator{defs: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+	typ := derefType(reflect.TypeOf(obj))
+
+	root := g.typeSchema(typ, true)
+	root.Schema = JSONSchemaDialect
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+// schemaGenerator JSON Schema 生成器
+type schemaGenerator struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+// typeSchema 生成类型的 Schema，inline 为 false 时结构体输出到 $defs
+func (g *schemaGenerator) typeSchema(typ reflect.Type, inline bool) *Schema {
+	typ = derefType(typ)
+	switch {
+	case typ == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case typ.Kind() == reflect.Struct:
+		if inline {
+			return g.structSchema(typ)
+		}
+		return &Schema{Ref: "#/$defs/" + g.define(typ)}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.typeSchema(typ.Elem(), false)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(typ.Elem(), false)}
+	default:
+		return &Schema{}
+	}
+}
+
+// define 将结构体定义输出到 $defs，返回定义名
+func (g *schemaGenerator) define(typ reflect.Type) string {
+	if name, ok := g.names[typ]; ok {
+		return name
+	}
+	name := typ.Name()
+	if name == "" {
+		name = "Object"
+	}
+	for i := 2; g.defs[name] != nil; i++ {
+		name = typ.Name() + strconv.Itoa(i)
+	}
+	g.names[typ] = name
+	// 先占位以支持递归类型
+	g.defs[name] = &Schema{}
+	*g.defs[name] = *g.structSchema(typ)
+	return name
+}
+
+// structSchema 生成结构体的 Schema，未声明 json 标签的内嵌结构体字段提升到当前层级
+func (g *schemaGenerator) structSchema(typ reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		if field.Anonymous && jsonName == "" && derefType(field.Type).Kind() == reflect.Struct {
+			embedded := g.structSchema(derefType(field.Type))
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+
+		prop := g.typeSchema(field.Type, false)
+		rules := splitRules(field.Tag.Get("validate"))
+		if applyRules(prop, field.Type, rules) {
+			s.Required = append(s.Required, jsonName)
+		}
+		if label := field.Tag.Get("label"); label != "" {
+			prop = withRef(prop)
+			prop.Title = label
+		}
+		if def, ok := field.Tag.Lookup("default"); ok {
+			prop = withRef(prop)
+			prop.Default = parseLiteral(derefType(field.Type), def)
+		}
+		s.Properties[jsonName] = prop
+	}
+	return s
+}
+
+// withRef 在 $ref 上附加注解时复制一份，避免修改共享定义
+func withRef(s *Schema) *Schema {
+	if s.Ref == "" {
+		return s
+	}
+	return &Schema{Ref: s.Ref}
+}
+
+// applyRules 将校验规则应用到 Schema，返回字段是否必填
+// dive 之后的规则作用于数组元素或 map 值
+func applyRules(s *Schema, typ reflect.Type, rules []string) bool {
+	typ = derefType(typ)
+	required := false
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			target := s.Items
+			if typ.Kind() == reflect.Map {
+				target = s.AdditionalProperties
+			}
+			if target != nil && target.Ref == "" && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map) {
+				applyRules(target, typ.Elem(), rules[i+1:])
+			}
+			return required
+		case "required":
+			required = true
+		case "omitempty", "":
+		default:
+			applyRule(s, typ, name, param)
+		}
+	}
+	return required
+}
+
+// applyRule 应用单条规则，无法表达的规则忽略
+func applyRule(s *Schema, typ reflect.Type, name, param string) {
+	if strings.Contains(name, "|") {
+		return
+	}
+	switch name {
+	case "min", "gte":
+		setLowerBound(s, typ, param, false)
+	case "max", "lte":
+		setUpperBound(s, typ, param, false)
+	case "gt":
+		setLowerBound(s, typ, param, true)
+	case "lt":
+		setUpperBound(s, typ, param, true)
+	case "len":
+		setLowerBound(s, typ, param, false)
+		setUpperBound(s, typ, param, false)
+	case "eq":
+		s.Const = parseLiteral(typ, param)
+	case "oneof":
+		for _, v := range splitOneOf(param) {
+			s.Enum = append(s.Enum, parseLiteral(typ, v))
+		}
+	case "unique":
+		if s.Type == "array" {
+			s.UniqueItems = true
+		}
+	case "ip":
+		s.AnyOf = []*Schema{{Format: "ipv4"}, {Format: "ipv6"}}
+	case "datetime":
+		s.Pattern = `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`
+	default:
+		if format, ok := formats[name]; ok {
+			s.Format = format
+		} else if re, ok := lookupPattern(name); ok {
+			s.Pattern = ecmaPattern(re.String())
+		}
+	}
+}
+
+// setLowerBound 按类型设置下限：字符串为长度，数组为元素数，map 为属性数，数字为取值
+func setLowerBound(s *Schema, typ reflect.Type, param string, exclusive bool) {
+	switch s.Type {
+	case "integer", "number":
+		if v, err := strconv.ParseFloat(param, 64); err == nil {
+			if exclusive {
+				s.ExclusiveMinimum = &v
+			} else {
+				s.Minimum = &v
+			}
+		}
+		return
+	}
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	if exclusive {
+		n++
+	}
+	switch {
+	case s.Type == "string":
+		s.MinLength = &n
+	case s.Type == "array":
+		s.MinItems = &n
+	case s.Type == "object" && typ.Kind() == reflect.Map:
+		s.MinProperties = &n
+	}
+}
+
+// setUpperBound 按类型设置上限
+func setUpperBound(s *Schema, typ reflect.Type, param string, exclusive bool) {
+	switch s.Type {
+	case "integer", "number":
+		if v, err := strconv.ParseFloat(param, 64); err == nil {
+			if exclusive {
+				s.ExclusiveMaximum = &v
+			} else {
+				s.Maximum = &v
+			}
+		}
+		return
+	}
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	if exclusive {
+		n--
+	}
+	switch {
+	case s.Type == "string":
+		s.MaxLength = &n
+	case s.Type == "array":
+		s.MaxItems = &n
+	case s.Type == "object" && typ.Kind() == reflect.Map:
+		s.MaxProperties = &n
+	}
+}
+
+// splitRules 拆分 validate 标签，0x2C 为转义的逗号
+func splitRules(tag string) []string {
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	rules := strings.Split(tag, ",")
+	for i, r := range rules {
+		rules[i] = strings.ReplaceAll(strings.TrimSpace(r), "0x2C", ",")
+	}
+	return rules
+}
+
+// splitOneOf 拆分 oneof 参数，支持单引号包裹含空格的值
+func splitOneOf(param string) []string {
+	var values []string
+	for len(param) > 0 {
+		param = strings.TrimLeft(param, " ")
+		if param == "" {
+			break
+		}
+		if param[0] == '\'' {
+			if end := strings.IndexByte(param[1:], '\''); end >= 0 {
+				values = append(values, param[1:end+1])
+				param = param[end+2:]
+				continue
+			}
+		}
+		v, rest, _ := strings.Cut(param, " ")
+		values = append(values, v)
+		param = rest
+	}
+	return values
+}
+
+// parseLiteral 按字段类型解析字面量
+func parseLiteral(typ reflect.Type, v string) interface{} {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
+// unicodeEscape Go 正则中的 \x{4e00} 形式转义
+var unicodeEscape = regexp.MustCompile(`\\x\{([0-9a-fA-F]{1,4})\}`)
+
+// ecmaPattern 将 Go 正则转换为 JSON Schema 使用的 ECMA-262 语法
+func ecmaPattern(pattern string) string {
+	return unicodeEscape.ReplaceAllStringFunc(pattern, func(m string) string {
+		hex := unicodeEscape.FindStringSubmatch(m)[1]
+		return `\u` + strings.Repeat("0", 4-len(hex)) + hex
+	})
+}
+
+// derefType 解引用指针类型
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
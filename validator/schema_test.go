@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type schemaBase struct {
+	ID int64 `json:"id" validate:"gt=0"`
+}
+
+type schemaUser struct {
+	schemaBase
+	Name     string            `json:"name" label:"User name" validate:"required,min=2,max=32"`
+	Email    string            `json:"email" validate:"omitempty,email"`
+	Mobile   string            `json:"mobile" validate:"mobile"`
+	Nickname string            `json:"nickname" validate:"chinese"`
+	Role     string            `json:"role" default:"member" validate:"oneof=admin member 'read only'"`
+	Age      int               `json:"age" validate:"gte=18,lt=150"`
+	Tags     []string          `json:"tags" validate:"max=5,unique,dive,len=3"`
+	Home     *schemaAddress    `json:"home"`
+	Offices  []schemaAddress   `json:"offices" validate:"required,min=1"`
+	Meta     map[string]string `json:"meta"`
+	Birthday time.Time         `json:"birthday"`
+	Secret   string            `json:"-"`
+}
+
+// 测试由校验标签导出 JSON Schema
+func TestJSONSchema(t *testing.T) {
+	data, err := json.Marshal(JSONSchema(&schemaUser{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+
+	for _, want := range []string{
+		`"$schema":"https://json-schema.org/draft/2020-12/schema"`,
+		`"id":{"type":"integer","exclusiveMinimum":0}`,
+		`"name":{"title":"User name","type":"string","minLength":2,"maxLength":32}`,
+		`"email":{"type":"string","format":"email"}`,
+		`"mobile":{"type":"string","pattern":"^1[3-9]\\d{9}$"}`,
+		`"nickname":{"type":"string","pattern":"^[\\u4e00-\\u9fa5]+$"}`,
+		`"role":{"type":"string","enum":["admin","member","read only"],"default":"member"}`,
+		`"age":{"type":"integer","minimum":18,"exclusiveMaximum":150}`,
+		`"tags":{"type":"array","maxItems":5,"uniqueItems":true,"items":{"type":"string","minLength":3,"maxLength":3}}`,
+		`"home":{"$ref":"#/$defs/schemaAddress"}`,
+		`"offices":{"type":"array","minItems":1,"items":{"$ref":"#/$defs/schemaAddress"}}`,
+		`"meta":{"type":"object","additionalProperties":{"type":"string"}}`,
+		`"birthday":{"type":"string","format":"date-time"}`,
+		`"required":["name","offices"]`,
+		`"$defs":{"schemaAddress":{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("schema missing %s\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Secret") {
+		t.Errorf("json:\"-\" field exported: %s", got)
+	}
+}
+
+// 测试正则规则注册后同时用于校验与 Schema 导出
+func TestRegisterPattern(t *testing.T) {
+	if err := RegisterPattern("sku", `^[A-Z]{3}-\d+$`); err != nil {
+		t.Fatal(err)
+	}
+	type product struct {
+		SKU string `json:"sku" validate:"sku"`
+	}
+	if err := ValidateStruct(&product{SKU: "abc-1"}); err == nil {
+		t.Fatal("invalid sku accepted")
+	}
+	if p := JSONSchema(product{}).Properties["sku"]; p.Pattern != `^[A-Z]{3}-\d+$` {
+		t.Fatalf("sku pattern = %q", p.Pattern)
+	}
+}
+
+// 测试 RegisterPattern 与 JSONSchema 并发调用，配合 -race 检查数据竞争
+func TestRegisterPatternConcurrent(t *testing.T) {
+	type order struct {
+		Code string `json:"code" validate:"order_code"`
+	}
+	started := make(chan struct{})
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		JSONSchema(order{})
+		close(started)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				JSONSchema(order{})
+			}
+		}
+	}()
+	<-started
+	for i := 0; i < 100; i++ {
+		if err := RegisterPattern("order_code", `^O\d+$`); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	<-done
+	if p := JSONSchema(order{}).Properties["code"]; p.Pattern != `^O\d+$` {
+		t.Fatalf("code pattern = %q", p.Pattern)
+	}
+}
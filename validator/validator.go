@@ -1,15 +1,23 @@
 package validator
 
 import (
-	"fmt"
-	"reflect"
+	"errors"
 	"regexp"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// ValidationErrors 校验错误列表
+type ValidationErrors = validator.ValidationErrors
+
+// StructLevel 结构体级校验上下文，通过 ReportError 报告字段错误
+type StructLevel = validator.StructLevel
+
+// StructLevelFunc 结构体级校验函数
+type StructLevelFunc = validator.StructLevelFunc
+
 // 全局验证器实例
 var validate *validator.Validate
 
@@ -18,20 +26,60 @@ func init() {
 
 	// 注册自定义验证器
 	registerCustomValidators()
+	registerFileValidators()
+}
+
+// patternsMu 保护 patterns，RegisterPattern 写入与 JSONSchema 读取可能并发
+var patternsMu sync.RWMutex
+
+// patterns 预编译的格式规则，键为验证标签
+var patterns = map[string]*regexp.Regexp{
+	"mobile":       regexp.MustCompile(`^1[3-9]\d{9}$`),
+	"chinese":      regexp.MustCompile(`^[\x{4e00}-\x{9fa5}]+$`),
+	"english":      regexp.MustCompile(`^[a-zA-Z]+$`),
+	"numeric":      regexp.MustCompile(`^\d+$`),
+	"alphanumeric": regexp.MustCompile(`^[a-zA-Z0-9]+$`),
+	"url":          regexp.MustCompile(`^(http|https)://[a-zA-Z0-9\-\.]+\.[a-zA-Z]{2,}(?:/[a-zA-Z0-9\-\._~:/?#[\]@!$&'()*+,;=]*)?$`),
+	"email":        regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`),
+	"postcode":     regexp.MustCompile(`^\d{6}$`),
+	"chinese_name": regexp.MustCompile(`^[\x{4e00}-\x{9fa5}]{2,}$`),
+	"english_name": regexp.MustCompile(`^[a-zA-Z\s]{2,}$`),
 }
 
 // 注册自定义验证器
 func registerCustomValidators() {
-	// 手机号验证
-	validate.RegisterValidation("mobile", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^1[3-9]\d{9}$`).MatchString(value)
-	})
+	// 格式验证：手机号、中文、英文、数字、字母数字、URL、邮箱、邮政编码、中文姓名、英文姓名
+	for tag, pattern := range patterns {
+		pattern := pattern
+		validate.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return pattern.MatchString(fl.Field().String())
+		})
+	}
 
-	// 身份证号验证
+	// 身份证号验证，校验出生日期与 GB 11643 校验码
 	validate.RegisterValidation("idcard", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[1-9]\d{5}(19|20)\d{2}(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])\d{3}[\dXx]$`).MatchString(value)
+		return IsIDCard(fl.Field().String())
+	})
+
+	// 银行卡号验证，校验 Luhn 校验位
+	validate.RegisterValidation("bankcard", func(fl validator.FieldLevel) bool {
+		return IsBankCard(fl.Field().String())
+	})
+
+	// 社会信用代码验证，校验 GB 32100 校验码
+	validate.RegisterValidation("credit_code", func(fl validator.FieldLevel) bool {
+		return IsCreditCode(fl.Field().String())
+	})
+
+	// IP地址验证
+	validate.RegisterValidation("ip", func(fl validator.FieldLevel) bool {
+		return IsIP(fl.Field().String())
+	})
+	validate.RegisterValidation("ipv4", func(fl validator.FieldLevel) bool {
+		return IsIPv4(fl.Field().String())
+	})
+	validate.RegisterValidation("ipv6", func(fl validator.FieldLevel) bool {
+		return IsIPv6(fl.Field().String())
 	})
 
 	// 密码强度验证
@@ -56,30 +104,6 @@ func registerCustomValidators() {
 		return hasUpper && hasLower && hasNumber && hasSpecial
 	})
 
-	// 中文验证
-	validate.RegisterValidation("chinese", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[\u4e00-\u9fa5]+$`).MatchString(value)
-	})
-
-	// 英文验证
-	validate.RegisterValidation("english", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[a-zA-Z]+$`).MatchString(value)
-	})
-
-	// 数字验证
-	validate.RegisterValidation("numeric", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^\d+$`).MatchString(value)
-	})
-
-	// 字母数字验证
-	validate.RegisterValidation("alphanumeric", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString(value)
-	})
-
 	// 日期验证
 	validate.RegisterValidation("date", func(fl validator.FieldLevel) bool {
 		value := fl.Field().String()
@@ -93,54 +117,6 @@ func registerCustomValidators() {
 		_, err := time.Parse("2006-01-02 15:04:05", value)
 		return err == nil
 	})
-
-	// IP地址验证
-	validate.RegisterValidation("ip", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}$`).MatchString(value)
-	})
-
-	// URL验证
-	validate.RegisterValidation("url", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^(http|https)://[a-zA-Z0-9\-\.]+\.[a-zA-Z]{2,}(?:/[a-zA-Z0-9\-\._~:/?#[\]@!$&'()*+,;=]*)?$`).MatchString(value)
-	})
-
-	// 邮箱验证
-	validate.RegisterValidation("email", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`).MatchString(value)
-	})
-
-	// 邮政编码验证
-	validate.RegisterValidation("postcode", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^\d{6}$`).MatchString(value)
-	})
-
-	// 中文姓名验证
-	validate.RegisterValidation("chinese_name", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[\u4e00-\u9fa5]{2,}$`).MatchString(value)
-	})
-
-	// 英文姓名验证
-	validate.RegisterValidation("english_name", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[a-zA-Z\s]{2,}$`).MatchString(value)
-	})
-
-	// 银行卡号验证
-	validate.RegisterValidation("bankcard", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^\d{16,19}$`).MatchString(value)
-	})
-
-	// 社会信用代码验证
-	validate.RegisterValidation("credit_code", func(fl validator.FieldLevel) bool {
-		value := fl.Field().String()
-		return regexp.MustCompile(`^[0-9A-HJ-NPQRTUWXY]{2}\d{6}[0-9A-HJ-NPQRTUWXY]{10}$`).MatchString(value)
-	})
 }
 
 // RegisterValidation 注册自定义验证器
@@ -148,107 +124,54 @@ func RegisterValidation(tag string, fn validator.Func) error {
 	return validate.RegisterValidation(tag, fn)
 }
 
+// RegisterStructValidation 注册结构体级校验函数，用于多个字段联合约束等无法用标签表达的规则
+// 通过 sl.ReportError(value, fieldName, structFieldName, tag, param) 报告错误，tag 可配合 RegisterMessage 注册自定义消息
+func RegisterStructValidation(fn StructLevelFunc, types ...interface{}) {
+	validate.RegisterStructValidation(fn, types...)
+}
+
 // ValidateStruct 验证结构体
 func ValidateStruct(obj interface{}) error {
+	defer explainTagPanic()
 	return validate.Struct(obj)
 }
 
 // ValidateVar 验证变量
 func ValidateVar(field interface{}, tag string) error {
+	defer explainTagPanic()
 	return validate.Var(field, tag)
 }
 
 // GetValidationErrors 获取验证错误信息
 func GetValidationErrors(err error) map[string]string {
-	errors := make(map[string]string)
+	result := make(map[string]string)
 	if err == nil {
-		return errors
+		return result
 	}
 
-	validationErrors, ok := err.(validator.ValidationErrors)
-	if !ok {
-		errors["error"] = err.Error()
-		return errors
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		result["error"] = err.Error()
+		return result
 	}
 
 	for _, e := range validationErrors {
+		// 键为结构体字段名；需要 json 字段路径时使用 binding 包返回的 *binding.Error
 		field := e.Field()
-		tag := e.Tag()
-		param := e.Param()
-
-		// 获取字段的 json 标签
-		t := reflect.TypeOf(e.Value())
-		if t.Kind() == reflect.Ptr {
-			t = t.Elem()
-		}
-		if f, ok := t.FieldByName(field); ok {
-			if jsonTag := f.Tag.Get("json"); jsonTag != "" {
-				field = strings.Split(jsonTag, ",")[0]
-			}
-		}
-
-		// 生成错误信息
-		message := getErrorMessage(field, tag, param)
-		errors[field] = message
+		result[field] = getErrorMessage(field, e.Tag(), e.Param())
 	}
 
-	return errors
+	return result
+}
+
+// ErrorMessage 获取字段未通过指定规则时默认语言的错误信息
+func ErrorMessage(field, tag, param string) string {
+	return getErrorMessage(field, tag, param)
 }
 
-// getErrorMessage 获取错误信息
+// getErrorMessage 获取默认语言的错误信息
 func getErrorMessage(field, tag, param string) string {
-	switch tag {
-	case "required":
-		return fmt.Sprintf("%s 不能为空", field)
-	case "email":
-		return fmt.Sprintf("%s 必须是有效的邮箱地址", field)
-	case "url":
-		return fmt.Sprintf("%s 必须是有效的URL地址", field)
-	case "min":
-		return fmt.Sprintf("%s 不能小于 %s", field, param)
-	case "max":
-		return fmt.Sprintf("%s 不能大于 %s", field, param)
-	case "len":
-		return fmt.Sprintf("%s 长度必须为 %s", field, param)
-	case "oneof":
-		return fmt.Sprintf("%s 必须是以下值之一: %s", field, param)
-	case "unique":
-		return fmt.Sprintf("%s 不能重复", field)
-	case "alpha":
-		return fmt.Sprintf("%s 只能包含字母", field)
-	case "numeric":
-		return fmt.Sprintf("%s 只能包含数字", field)
-	case "alphanumeric":
-		return fmt.Sprintf("%s 只能包含字母和数字", field)
-	case "datetime":
-		return fmt.Sprintf("%s 必须是有效的日期时间格式", field)
-	case "mobile":
-		return fmt.Sprintf("%s 必须是有效的手机号", field)
-	case "idcard":
-		return fmt.Sprintf("%s 必须是有效的身份证号", field)
-	case "password":
-		return fmt.Sprintf("%s 必须包含大小写字母、数字和特殊字符，且长度不少于8位", field)
-	case "chinese":
-		return fmt.Sprintf("%s 只能包含中文字符", field)
-	case "english":
-		return fmt.Sprintf("%s 只能包含英文字符", field)
-	case "date":
-		return fmt.Sprintf("%s 必须是有效的日期格式", field)
-	case "ip":
-		return fmt.Sprintf("%s 必须是有效的IP地址", field)
-	case "postcode":
-		return fmt.Sprintf("%s 必须是有效的邮政编码", field)
-	case "chinese_name":
-		return fmt.Sprintf("%s 必须是有效的中文姓名", field)
-	case "english_name":
-		return fmt.Sprintf("%s 必须是有效的英文姓名", field)
-	case "bankcard":
-		return fmt.Sprintf("%s 必须是有效的银行卡号", field)
-	case "credit_code":
-		return fmt.Sprintf("%s 必须是有效的社会信用代码", field)
-	default:
-		return fmt.Sprintf("%s 验证失败", field)
-	}
+	return LocalizedMessage(Translator().GetDefaultLang(), field, tag, param)
 }
 
 // 常用验证标签
@@ -272,9 +195,14 @@ const (
 	English     = "english"      // 英文
 	Date        = "date"         // 日期
 	IP          = "ip"           // IP地址
+	IPv4        = "ipv4"         // IPv4地址
+	IPv6        = "ipv6"         // IPv6地址
 	PostCode    = "postcode"     // 邮政编码
 	ChineseName = "chinese_name" // 中文姓名
 	EnglishName = "english_name" // 英文姓名
 	BankCard    = "bankcard"     // 银行卡号
 	CreditCode  = "credit_code"  // 社会信用代码
+	FileMax     = "file_max"     // 文件大小上限
+	MIME        = "mime"         // 文件类型
+	MaxFiles    = "max_files"    // 文件数量上限
 )
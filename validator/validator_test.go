@@ -0,0 +1,21 @@
+package validator
+
+import "testing"
+
+// 测试 GetValidationErrors 以结构体字段名为键，不受 json 标签影响
+func TestGetValidationErrorsKeys(t *testing.T) {
+	type request struct {
+		UserName string `json:"user_name" validate:"required"`
+		Age      int    `json:"-" validate:"min=18"`
+	}
+
+	errs := GetValidationErrors(ValidateStruct(&request{Age: 1}))
+	if len(errs) != 2 {
+		t.Fatalf("errors = %v, want 2", errs)
+	}
+	for _, key := range []string{"UserName", "Age"} {
+		if _, ok := errs[key]; !ok {
+			t.Errorf("missing key %s in %v", key, errs)
+		}
+	}
+}